@@ -2,8 +2,10 @@ package main
 
 import (
 	"os"
+	"strings"
 
 	"fmt"
+	"path/filepath"
 
 	"github.com/Oppodelldog/git-commit-hook/config"
 	"github.com/Oppodelldog/git-commit-hook/hook"
@@ -13,16 +15,28 @@ import (
 
 type callWithIntResult func() int
 type rewriteCommitMessageFuncDef func(string, hook.CommitMessageModifier) error
+type prepareCommitMessageFuncDef func(string, string, hook.CommitMessagePreparer) error
 type exitFuncDef func(code int)
 
 var diagnosticsFunc = callWithIntResult(subcommand.NewDiagCommand().Diagnostics)
 var testFunc = callWithIntResult(subcommand.NewTestCommand().Test)
 var installFunc = callWithIntResult(subcommand.NewInstallCommand().Install)
 var uninstallFunc = callWithIntResult(subcommand.NewUninstallerCommand().Uninstall)
+var lintFunc = callWithIntResult(subcommand.NewLintCommand().Lint)
 var rewriteCommitMessageFunc = rewriteCommitMessageFuncDef(hook.RewriteCommitMessage)
+var prepareCommitMessageFunc = prepareCommitMessageFuncDef(hook.PrepareCommitMessage)
 var exitFunc = exitFuncDef(os.Exit)
 
 func main() {
+	args, configFilePath, err := parseGlobalFlags(os.Args)
+	if err != nil {
+		fmt.Print(err)
+		exitFunc(1)
+		return
+	}
+	os.Args = args
+	config.SetConfigurationFilePath(configFilePath)
+
 	if len(os.Args) < 2 {
 		fmt.Println("too few arguments")
 		fmt.Println("")
@@ -30,6 +44,9 @@ func main() {
 		fmt.Println("install 	- helps to install git-commit-hook")
 		fmt.Println("uninstall 	- helps to uninstall git-commit-hook")
 		fmt.Println("test 		- helps to test configuration with manual inputs")
+		fmt.Println("lint 		- checks the configuration files strictly")
+		fmt.Println("")
+		fmt.Println("--config <file>	- use the given configuration file, works with every sub command")
 		exitFunc(0)
 		return
 	}
@@ -50,6 +67,10 @@ func main() {
 		result := diagnosticsFunc()
 		exitFunc(result)
 		return
+	} else if os.Args[1] == "lint" {
+		result := lintFunc()
+		exitFunc(result)
+		return
 	}
 
 	commitMessageFile := os.Args[1]
@@ -59,15 +80,27 @@ func main() {
 		return
 	}
 
-	projectConfiguration, err := config.LoadProjectConfigurationFromCommitMessageFileDir(commitMessageFile)
+	if config.IsSkippedByEnvironment() {
+		exitFunc(0)
+		return
+	}
+
+	projectConfiguration, err := loadProjectConfiguration(commitMessageFile)
 	if err != nil {
 		fmt.Print(err)
 		exitFunc(1)
 		return
 	}
 
-	commitMessageModifier := hook.NewCommitMessageModifier(projectConfiguration)
-	err = rewriteCommitMessageFunc(commitMessageFile, commitMessageModifier)
+	if filepath.Base(os.Args[0]) == hook.PrepareCommitMessageHookName {
+		var source string
+		if len(os.Args) > 2 {
+			source = os.Args[2]
+		}
+		err = prepareCommitMessageFunc(commitMessageFile, source, hook.NewCommitMessagePreparer(projectConfiguration))
+	} else {
+		err = rewriteCommitMessageFunc(commitMessageFile, hook.NewCommitMessageModifier(projectConfiguration))
+	}
 	if err != nil {
 		fmt.Print(err)
 		exitFunc(1)
@@ -76,3 +109,39 @@ func main() {
 
 	exitFunc(0)
 }
+
+// loadProjectConfiguration loads the project set by GIT_COMMIT_HOOK_PROJECT or else the project the given
+// commit message file belongs to.
+func loadProjectConfiguration(commitMessageFile string) (config.Project, error) {
+	if projectName := config.GetProjectNameFromEnvironment(); projectName != "" {
+		return config.LoadProjectConfigurationByName(projectName)
+	}
+
+	return config.LoadProjectConfigurationFromCommitMessageFileDir(commitMessageFile)
+}
+
+// parseGlobalFlags removes the global flags from the given arguments and returns the configuration file passed by
+// --config. Global flags may be given before or after the sub command.
+func parseGlobalFlags(args []string) ([]string, string, error) {
+	var configFilePath string
+	var remainingArgs []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case i == 0:
+			remainingArgs = append(remainingArgs, arg)
+		case arg == "--config" || arg == "-config":
+			if i+1 >= len(args) {
+				return nil, "", errors.Errorf("flag needs an argument: %s", arg)
+			}
+			i++
+			configFilePath = args[i]
+		case strings.HasPrefix(arg, "--config=") || strings.HasPrefix(arg, "-config="):
+			configFilePath = arg[strings.Index(arg, "=")+1:]
+		default:
+			remainingArgs = append(remainingArgs, arg)
+		}
+	}
+
+	return remainingArgs, configFilePath, nil
+}
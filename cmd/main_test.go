@@ -11,6 +11,8 @@ import (
 
 	"path"
 
+	"github.com/Oppodelldog/git-commit-hook/config"
+	"github.com/Oppodelldog/git-commit-hook/hook"
 	"github.com/Oppodelldog/git-commit-hook/subcommand"
 	"github.com/Oppodelldog/git-commit-hook/testhelper"
 	"github.com/stretchr/testify/assert"
@@ -24,19 +26,23 @@ const commitMessageFile = ".git/COMMIT_EDITMSG"
 var originals = struct {
 	osArgs                   []string
 	rewriteCommitMessageFunc rewriteCommitMessageFuncDef
+	prepareCommitMessageFunc prepareCommitMessageFuncDef
 	diagnosticsFunc          callWithIntResult
 	testFunc                 callWithIntResult
 	installFunc              callWithIntResult
 	uninstallFunc            callWithIntResult
+	lintFunc                 callWithIntResult
 	exitFunc                 exitFuncDef
 	osStdout                 *os.File
 }{
 	osArgs:                   os.Args,
 	rewriteCommitMessageFunc: rewriteCommitMessageFunc,
+	prepareCommitMessageFunc: prepareCommitMessageFunc,
 	diagnosticsFunc:          diagnosticsFunc,
 	testFunc:                 testFunc,
 	installFunc:              installFunc,
 	uninstallFunc:            uninstallFunc,
+	lintFunc:                 lintFunc,
 	exitFunc:                 exitFunc,
 	osStdout:                 os.Stdout,
 }
@@ -47,8 +53,10 @@ func restoreOriginals() {
 	testFunc = originals.testFunc
 	installFunc = originals.installFunc
 	uninstallFunc = originals.uninstallFunc
+	lintFunc = originals.lintFunc
 	os.Args = originals.osArgs
 	rewriteCommitMessageFunc = originals.rewriteCommitMessageFunc
+	prepareCommitMessageFunc = originals.prepareCommitMessageFunc
 	exitFunc = originals.exitFunc
 	os.Stdout = originals.osStdout
 	os.RemoveAll(testhelper.TestPath)
@@ -71,6 +79,52 @@ func TestMain_HappyPath(t *testing.T) {
 	assertCommitMessage(t, expectedCommitMessage)
 }
 
+func TestMain_AmendCommit_CommitMessageIsNotModifiedTwice(t *testing.T) {
+	defer restoreOriginals()
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.CleanupTestEnvironment(t)
+
+	initGitRepositoryWithBranchAndConfig(t, featureBranch)
+	setCommitMessage(t, "initial commit")
+	prepareGitHookCall()
+
+	assertProgramExistsWith(t, 0)
+
+	main()
+	main()
+
+	expectedCommitMessage := fmt.Sprintf("%s: initial commit", featureBranch)
+	assertCommitMessage(t, expectedCommitMessage)
+}
+
+func TestMain_CalledAsPrepareCommitMessageHook(t *testing.T) {
+	defer restoreOriginals()
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.CleanupTestEnvironment(t)
+
+	initGitRepositoryWithBranchAndConfig(t, featureBranch)
+	setCommitMessage(t, "initial commit")
+	os.Args = []string{".git/hooks/prepare-commit-msg", commitMessageFile, "template"}
+
+	prepareCommitMessageFuncCalled := false
+	prepareCommitMessageFunc = func(file string, source string, preparer hook.CommitMessagePreparer) error {
+		prepareCommitMessageFuncCalled = true
+		assert.Exactly(t, commitMessageFile, file)
+		assert.Exactly(t, "template", source)
+		return nil
+	}
+	rewriteCommitMessageFunc = func(string, hook.CommitMessageModifier) error {
+		t.Fatal("rewriteCommitMessageFunc must not be called by prepare-commit-msg hook")
+		return nil
+	}
+
+	assertProgramExistsWith(t, 0)
+
+	main()
+
+	assert.True(t, prepareCommitMessageFuncCalled)
+}
+
 func TestMain_ConfigurationNotFound(t *testing.T) {
 	defer restoreOriginals()
 	defer testhelper.CleanupTestEnvironment(t)
@@ -95,6 +149,114 @@ func TestMain_ConfigurationNotFound(t *testing.T) {
 	assertCommitMessage(t, initialCommitMessage)
 }
 
+func TestMain_SkippedByEnvironment(t *testing.T) {
+	defer restoreOriginals()
+	defer testhelper.CleanupTestEnvironment(t)
+	defer os.Unsetenv(config.EnvSkip)
+	testhelper.CleanupTestEnvironment(t)
+
+	initGitRepositoryWithBranchAndConfig(t, nonFeatureBranch)
+	setCommitMessage(t, "@noissue rc-fix")
+	prepareGitHookCall()
+	os.Setenv(config.EnvSkip, "1")
+
+	assertProgramExistsWith(t, 0)
+
+	main()
+
+	assertCommitMessage(t, "@noissue rc-fix")
+}
+
+func TestMain_BranchAndProjectFromEnvironment(t *testing.T) {
+	defer restoreOriginals()
+	defer testhelper.CleanupTestEnvironment(t)
+	defer os.Unsetenv(config.EnvBranch)
+	defer os.Unsetenv(config.EnvProject)
+	testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	testhelper.InitGitRepository(t, nonFeatureBranch)
+	writeConfigFile(t, ".git/git-commit-hook.yaml", `ci project:
+  path: /some/other/repository/.git
+  branch:
+  - name: feature
+    pattern: ^feature/.*$
+  template:
+    feature: '{{.BranchName}}: {{.CommitMessage}}'
+`)
+	setCommitMessage(t, "initial commit")
+	prepareGitHookCall()
+	os.Setenv(config.EnvBranch, featureBranch)
+	os.Setenv(config.EnvProject, "ci project")
+
+	assertProgramExistsWith(t, 0)
+
+	main()
+
+	assertCommitMessage(t, fmt.Sprintf("%s: initial commit", featureBranch))
+}
+
+func TestMain_ConfigFlag(t *testing.T) {
+	defer restoreOriginals()
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.CleanupTestEnvironment(t)
+
+	initGitRepositoryWithBranchAndConfig(t, featureBranch)
+	configDir := path.Join(testhelper.TestPath, "ci")
+	if err := os.MkdirAll(configDir, 0777); err != nil {
+		t.Fatalf("Did not expect os.MkdirAll to return an error, but got: %v", err)
+	}
+	if err := os.Rename(path.Join(testhelper.TestPath, ".git", "git-commit-hook.yaml"), path.Join(configDir, "hook.yaml")); err != nil {
+		t.Fatalf("Did not expect os.Rename to return an error, but got: %v", err)
+	}
+	setCommitMessage(t, "initial commit")
+	os.Args = []string{"git", "--config", "ci/hook.yaml", commitMessageFile}
+
+	assertProgramExistsWith(t, 0)
+
+	main()
+
+	assertCommitMessage(t, fmt.Sprintf("%s: initial commit", featureBranch))
+}
+
+func TestParseGlobalFlags(t *testing.T) {
+	testDataSet := map[string]struct {
+		args                   []string
+		expectedArgs           []string
+		expectedConfigFilePath string
+	}{
+		"no flags":           {[]string{"git-commit-hook", "diag"}, []string{"git-commit-hook", "diag"}, ""},
+		"config flag":        {[]string{"git-commit-hook", "--config", "ci.yaml", "diag"}, []string{"git-commit-hook", "diag"}, "ci.yaml"},
+		"config flag with =": {[]string{"git-commit-hook", "--config=ci.yaml", "test", "-m", "x"}, []string{"git-commit-hook", "test", "-m", "x"}, "ci.yaml"},
+		"single dash":        {[]string{"git-commit-hook", "-config", "ci.yaml", ".git/COMMIT_EDITMSG"}, []string{"git-commit-hook", ".git/COMMIT_EDITMSG"}, "ci.yaml"},
+		"after sub command":  {[]string{"git-commit-hook", "lint", "--config", "ci.yaml"}, []string{"git-commit-hook", "lint"}, "ci.yaml"},
+		"only flag":          {[]string{"git-commit-hook", "--config=ci.yaml"}, []string{"git-commit-hook"}, "ci.yaml"},
+	}
+
+	for testCaseName, testData := range testDataSet {
+		t.Run(testCaseName, func(t *testing.T) {
+			args, configFilePath, err := parseGlobalFlags(testData.args)
+
+			assert.NoError(t, err)
+			assert.Exactly(t, testData.expectedArgs, args)
+			assert.Exactly(t, testData.expectedConfigFilePath, configFilePath)
+		})
+	}
+}
+
+func TestParseGlobalFlags_MissingArgument(t *testing.T) {
+	args, _, err := parseGlobalFlags([]string{"git-commit-hook", "--config"})
+
+	assert.Nil(t, args)
+	assert.EqualError(t, err, "flag needs an argument: --config")
+}
+
+func writeConfigFile(t *testing.T, fileName string, content string) {
+	err := ioutil.WriteFile(fileName, []byte(content), 0666)
+	if err != nil {
+		t.Fatalf("Did not exepect ioutil.WriteFile to return an error, but got: %v", err)
+	}
+}
+
 func readCommitMessage(t *testing.T) string {
 	b, err := ioutil.ReadFile(commitMessageFile)
 	if err != nil {
@@ -152,6 +314,7 @@ func TestMain_FirstArgIsSubCommand_AppropriateFuncCalled(t *testing.T) {
 		"install":   {"test", &installFunc},
 		"uninstall": {"test", &uninstallFunc},
 		"diag":      {"test", &diagnosticsFunc},
+		"lint":      {"test", &lintFunc},
 	}
 
 	for subCommandName, testData := range testDataSet {
@@ -225,6 +388,24 @@ func TestMain_ErrorCase_GitError(t *testing.T) {
 	assert.Exactly(t, "error modifying commit message: validation error for branch 'release/v0.1.2'", stdOutput)
 }
 
+func TestMain_ErrorCase_TicketIDInScissorsSectionIsIgnored(t *testing.T) {
+	defer restoreOriginals()
+
+	initGitRepositoryWithBranchAndConfig(t, nonFeatureBranch)
+	setCommitMessage(t, "rc-fix\n# Please enter the commit message\n# ------------------------ >8 ------------------------\n+PROJECT-123\n")
+	os.Args = []string{"git", commitMessageFile}
+	w, stdOutChannel := captureStdOut(t)
+
+	assertProgramExistsWith(t, 1)
+
+	main()
+
+	w.Close()
+
+	stdOutput := <-stdOutChannel
+	assert.Exactly(t, "error modifying commit message: validation error for branch 'release/v0.1.2'", stdOutput)
+}
+
 func TestMain_ExitFuncUsesAppropriateOsFunc(t *testing.T) {
 	assert.Exactly(t, reflect.ValueOf(os.Exit).Pointer(), reflect.ValueOf(exitFunc).Pointer())
 }
@@ -244,6 +425,10 @@ func TestMain_InstallFuncMappedCorrectly(t *testing.T) {
 func TestMain_UnnstallFuncMappedCorrectly(t *testing.T) {
 	assert.Exactly(t, reflect.ValueOf(subcommand.NewUninstallerCommand().Uninstall).Pointer(), reflect.ValueOf(uninstallFunc).Pointer())
 }
+
+func TestMain_LintFuncMappedCorrectly(t *testing.T) {
+	assert.Exactly(t, reflect.ValueOf(subcommand.NewLintCommand().Lint).Pointer(), reflect.ValueOf(lintFunc).Pointer())
+}
 func assertProgramExistsWith(t *testing.T, expectedExitCode int) {
 	exitFunc = func(exitCode int) {
 		assert.Exactly(t, expectedExitCode, exitCode)
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// BranchType defines a named branch type and the pattern that identifies it.
+	// Branch types with higher Priority are matched first.
+	BranchType struct {
+		Name     string            `yaml:"name"`
+		Pattern  BranchTypePattern `yaml:"pattern"`
+		Priority int               `yaml:"priority,omitempty"`
+	}
+
+	// BranchTypes is an ordered list of branch types. When resolving a branch type the first matching entry wins.
+	// In the config file it may be given as a list of BranchType or in the legacy form as a map of name and pattern,
+	// in which case the order of the file is kept.
+	BranchTypes []BranchType
+)
+
+// Ordered returns the branch types sorted by descending priority. Branch types having the same priority keep
+// the order in which they were defined.
+func (b BranchTypes) Ordered() BranchTypes {
+	ordered := make(BranchTypes, len(b))
+	copy(ordered, b)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Priority > ordered[j].Priority
+	})
+
+	return ordered
+}
+
+// UnmarshalYAML reads branch types either from a list or from the legacy map form.
+func (b *BranchTypes) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var list []BranchType
+	err := unmarshal(&list)
+	if err == nil {
+		*b = list
+		return nil
+	}
+	if _, isTypeError := err.(*yaml.TypeError); isTypeError && list != nil {
+		// it is a list, but some values have the wrong type
+		*b = list
+		return err
+	}
+
+	// the nodes of the patterns tell the order of the legacy map
+	var legacy map[string]yaml.Node
+	if err := unmarshal(&legacy); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(legacy))
+	for name := range legacy {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := legacy[names[i]], legacy[names[j]]
+		return a.Line < b.Line || a.Line == b.Line && a.Column < b.Column
+	})
+
+	branchTypes := make(BranchTypes, 0, len(legacy))
+	for _, name := range names {
+		patternNode := legacy[name]
+		if patternNode.ShortTag() != "!!str" {
+			return &yaml.TypeError{Errors: []string{
+				fmt.Sprintf("line %d: pattern of branch type '%s' must be a string", patternNode.Line, name),
+			}}
+		}
+		branchTypes = append(branchTypes, BranchType{Name: name, Pattern: BranchTypePattern(patternNode.Value)})
+	}
+	*b = branchTypes
+
+	return nil
+}
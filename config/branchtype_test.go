@@ -0,0 +1,74 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestBranchTypes_UnmarshalYAML_LegacyMapKeepsFileOrder(t *testing.T) {
+	yamlData := `
+zeta: "^zeta$"
+alpha: "^alpha$"
+feature: "^feature/.*$"
+`
+	var branchTypes BranchTypes
+	err := yaml.Unmarshal([]byte(yamlData), &branchTypes)
+	if err != nil {
+		t.Fatalf("Did not expect yaml.Unmarshal to return an error, but got: %v ", err)
+	}
+
+	expectedBranchTypes := BranchTypes{
+		{Name: "zeta", Pattern: `^zeta$`},
+		{Name: "alpha", Pattern: `^alpha$`},
+		{Name: "feature", Pattern: `^feature/.*$`},
+	}
+	assert.Exactly(t, expectedBranchTypes, branchTypes)
+}
+
+func TestBranchTypes_UnmarshalYAML_List(t *testing.T) {
+	yamlData := `
+- name: any
+  pattern: ".*"
+- name: feature
+  pattern: "^feature/.*$"
+  priority: 10
+`
+	var branchTypes BranchTypes
+	err := yaml.Unmarshal([]byte(yamlData), &branchTypes)
+	if err != nil {
+		t.Fatalf("Did not expect yaml.Unmarshal to return an error, but got: %v ", err)
+	}
+
+	expectedBranchTypes := BranchTypes{
+		{Name: "any", Pattern: `.*`},
+		{Name: "feature", Pattern: `^feature/.*$`, Priority: 10},
+	}
+	assert.Exactly(t, expectedBranchTypes, branchTypes)
+}
+
+func TestBranchTypes_UnmarshalYAML_InvalidLegacyPattern_ReturnsError(t *testing.T) {
+	var branchTypes BranchTypes
+	err := yaml.Unmarshal([]byte(`feature: [a, b]`), &branchTypes)
+
+	assert.Contains(t, err.Error(), "pattern of branch type 'feature' must be a string")
+}
+
+func TestBranchTypes_Ordered(t *testing.T) {
+	branchTypes := BranchTypes{
+		{Name: "a", Priority: 0},
+		{Name: "b", Priority: 5},
+		{Name: "c", Priority: 0},
+		{Name: "d", Priority: 5},
+		{Name: "e", Priority: -1},
+	}
+
+	var names []string
+	for _, branchType := range branchTypes.Ordered() {
+		names = append(names, branchType.Name)
+	}
+
+	assert.Exactly(t, []string{"b", "d", "a", "c", "e"}, names)
+	assert.Exactly(t, "a", branchTypes[0].Name, "Ordered must not modify the original list")
+}
@@ -1,6 +1,9 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type (
 	// Configuration is the data representation of the config file structure
@@ -19,22 +22,70 @@ type (
 
 // GetProjectByRepoPath returns a Project for the given git repository path
 func (c *Configuration) GetProjectByRepoPath(path string) (Project, error) {
-	for _, projectCfg := range *c {
-		if projectCfg.Path == path {
-			return projectCfg, nil
-		}
+	return c.GetProjectByRepoPaths(path)
+}
+
+// GetProjectByRepoPaths returns the Project that matches the given paths most specifically. Every project is matched
+// against all paths, if projects match equally specific, the one matching an earlier path wins.
+func (c *Configuration) GetProjectByRepoPaths(paths ...string) (Project, error) {
+	projectName, found := c.findMostSpecificProjectName(func(projectCfg Project) (bool, int, int) {
+		return matchProjectPaths(projectCfg.Path, paths)
+	})
+	if found {
+		return c.getProject(projectName), nil
 	}
 
-	return Project{}, fmt.Errorf("project configuration not found for path '%s'", path)
+	return Project{}, fmt.Errorf("project configuration not found for path '%s'", strings.Join(paths, "', '"))
 }
 
 // GetProjectByName returns a Project for the given project name
 func (c *Configuration) GetProjectByName(projectName string) (Project, error) {
-	for configProjectName, projectCfg := range *c {
-		if configProjectName == projectName {
-			return projectCfg, nil
-		}
+	if _, ok := (*c)[projectName]; ok {
+		return c.getProject(projectName), nil
 	}
 
 	return Project{}, fmt.Errorf("project configuration not found for project name '%s'", projectName)
 }
+
+// GetProjectByRemoteURLs returns the Project whose remote pattern matches one of the given remote urls.
+// If several projects match, the most specific one is returned.
+func (c *Configuration) GetProjectByRemoteURLs(remoteURLs ...string) (Project, error) {
+	projectName, found := c.findMostSpecificProjectName(func(projectCfg Project) (bool, int, int) {
+		matches, specificity := matchProjectRemote(projectCfg.Remote, remoteURLs)
+		return matches, specificity, 0
+	})
+	if found {
+		return c.getProject(projectName), nil
+	}
+
+	return Project{}, fmt.Errorf("project configuration not found for remote '%s'", strings.Join(remoteURLs, "', '"))
+}
+
+// getProject returns the project of the given name with its Name set
+func (c *Configuration) getProject(projectName string) Project {
+	projectCfg := (*c)[projectName]
+	projectCfg.Name = projectName
+
+	return projectCfg
+}
+
+// findMostSpecificProjectName returns the name of the project that matches with the highest specificity. Equally
+// specific matches are decided by the lower rank, then by the project name.
+func (c *Configuration) findMostSpecificProjectName(match func(Project) (matches bool, specificity int, rank int)) (string, bool) {
+	var bestProjectName string
+	bestSpecificity, bestRank := -1, 0
+	for projectName, projectCfg := range *c {
+		matches, specificity, rank := match(projectCfg)
+		if !matches {
+			continue
+		}
+		if specificity > bestSpecificity ||
+			specificity == bestSpecificity && (rank < bestRank || rank == bestRank && projectName < bestProjectName) {
+			bestProjectName = projectName
+			bestSpecificity = specificity
+			bestRank = rank
+		}
+	}
+
+	return bestProjectName, bestSpecificity >= 0
+}
@@ -14,7 +14,7 @@ func TestConfiguration_GetProjectConfiguration(t *testing.T) {
 		t.Fatalf("Did not expect GetProjectByRepoPath to return an error, but got: %v ", err)
 	}
 
-	assert.Exactly(t, cfg["test2"], projectCfg)
+	assert.Exactly(t, cfg.getProject("test2"), projectCfg)
 }
 
 func TestConfiguration_GetProjectConfigurationByName(t *testing.T) {
@@ -25,7 +25,7 @@ func TestConfiguration_GetProjectConfigurationByName(t *testing.T) {
 		t.Fatalf("Did not expect GetProjectByName to return an error, but got: %v ", err)
 	}
 
-	assert.Exactly(t, cfg[projectName], projectCfg)
+	assert.Exactly(t, cfg.getProject(projectName), projectCfg)
 }
 
 func TestGetProjectConfigurationByRepoPath_NotFound(t *testing.T) {
@@ -52,3 +52,37 @@ func createTestConfiguration() Configuration {
 	}
 	return cfg
 }
+
+func TestConfiguration_GetProjectByName_SetsName(t *testing.T) {
+	cfg := Configuration{"acme": Project{Path: "/home/dev/acme/.git"}}
+
+	projectCfg, err := cfg.GetProjectByName("acme")
+
+	assert.NoError(t, err)
+	assert.Exactly(t, Project{Name: "acme", Path: "/home/dev/acme/.git"}, projectCfg)
+	assert.Exactly(t, "", cfg["acme"].Name)
+}
+
+func TestConfiguration_GetProjectByRepoPaths_MostSpecificOfAllPaths(t *testing.T) {
+	cfg := Configuration{
+		"acme": Project{Path: "/x/acme/**"},
+		"svc":  Project{Path: "/x/acme/svc"},
+	}
+
+	projectCfg, err := cfg.GetProjectByRepoPaths("/x/acme/svc/.git", "/x/acme/svc")
+
+	assert.NoError(t, err)
+	assert.Exactly(t, cfg.getProject("svc"), projectCfg)
+}
+
+func TestConfiguration_GetProjectByRepoPaths_EquallySpecificEarlierPathWins(t *testing.T) {
+	cfg := Configuration{
+		"a-superproject": Project{Path: "/x/main/.git"},
+		"b-submodule":    Project{Path: "/x/main/.git/modules/sub"},
+	}
+
+	projectCfg, err := cfg.GetProjectByRepoPaths("/x/main/.git/modules/sub", "/x/main/.git")
+
+	assert.NoError(t, err)
+	assert.Exactly(t, cfg.getProject("b-submodule"), projectCfg)
+}
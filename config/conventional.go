@@ -0,0 +1,26 @@
+package config
+
+// DefaultConventionalCommitTypes holds the commit types allowed if no types are configured
+var DefaultConventionalCommitTypes = []string{"build", "chore", "ci", "docs", "feat", "fix", "perf", "refactor", "revert", "style", "test"}
+
+type (
+	// ConventionalCommitConfiguration enables validation of commit messages according to the conventional commits
+	// specification (https://www.conventionalcommits.org).
+	ConventionalCommitConfiguration struct {
+		// Types holds the allowed commit types, if empty DefaultConventionalCommitTypes are allowed
+		Types []string `yaml:"types,omitempty"`
+		// Scopes holds the allowed scopes, if empty any scope is allowed
+		Scopes []string `yaml:"scopes,omitempty"`
+		// RequireScope defines if a scope must be given
+		RequireScope bool `yaml:"requireScope,omitempty"`
+	}
+)
+
+// AllowedTypes returns the configured types or the DefaultConventionalCommitTypes if no types are configured.
+func (c ConventionalCommitConfiguration) AllowedTypes() []string {
+	if len(c.Types) == 0 {
+		return DefaultConventionalCommitTypes
+	}
+
+	return c.Types
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"strconv"
+)
+
+const (
+	// EnvConfig names the environment variable that points to the configuration file to use
+	EnvConfig = "GIT_COMMIT_HOOK_CONFIG"
+	// EnvSkip names the environment variable that disables the hook if it is set to a true value like 1 or true
+	EnvSkip = "GIT_COMMIT_HOOK_SKIP"
+	// EnvBranch names the environment variable that overrides the branch name of the current commit
+	EnvBranch = "GIT_COMMIT_HOOK_BRANCH"
+	// EnvProject names the environment variable that overrides the project the current commit is checked against
+	EnvProject = "GIT_COMMIT_HOOK_PROJECT"
+)
+
+// configurationFilePath is the configuration file given by the --config flag
+var configurationFilePath string
+
+// SetConfigurationFilePath sets the configuration file given by the --config flag, it takes precedence over
+// GIT_COMMIT_HOOK_CONFIG. An empty path restores the discovery of configuration files.
+func SetConfigurationFilePath(filePath string) {
+	configurationFilePath = filePath
+}
+
+// getExplicitConfigurationFilePath returns the configuration file given by the --config flag or GIT_COMMIT_HOOK_CONFIG.
+func getExplicitConfigurationFilePath() string {
+	if configurationFilePath != "" {
+		return configurationFilePath
+	}
+
+	return getEnvFunc(EnvConfig)
+}
+
+// IsSkippedByEnvironment tells whether GIT_COMMIT_HOOK_SKIP disables the hook.
+func IsSkippedByEnvironment() bool {
+	skip, err := strconv.ParseBool(getEnvFunc(EnvSkip))
+
+	return err == nil && skip
+}
+
+// GetBranchNameFromEnvironment returns the branch name set by GIT_COMMIT_HOOK_BRANCH, or an empty string.
+func GetBranchNameFromEnvironment() string {
+	return getEnvFunc(EnvBranch)
+}
+
+// GetProjectNameFromEnvironment returns the project name set by GIT_COMMIT_HOOK_PROJECT, or an empty string.
+func GetProjectNameFromEnvironment() string {
+	return getEnvFunc(EnvProject)
+}
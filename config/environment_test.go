@@ -0,0 +1,103 @@
+package config
+
+import (
+	"path"
+	"testing"
+
+	"github.com/Oppodelldog/git-commit-hook/testhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSkippedByEnvironment(t *testing.T) {
+	defer restoreProjectPathOriginals()
+
+	testDataSet := map[string]bool{
+		"":      false,
+		"0":     false,
+		"false": false,
+		"yes":   false,
+		"1":     true,
+		"true":  true,
+		"TRUE":  true,
+	}
+
+	for value, expectedSkip := range testDataSet {
+		t.Run(value, func(t *testing.T) {
+			getEnvFunc = func(key string) string {
+				return map[string]string{EnvSkip: value}[key]
+			}
+
+			assert.Exactly(t, expectedSkip, IsSkippedByEnvironment())
+		})
+	}
+}
+
+func TestGetBranchAndProjectNameFromEnvironment(t *testing.T) {
+	defer restoreProjectPathOriginals()
+	getEnvFunc = func(key string) string {
+		return map[string]string{EnvBranch: "feature/PROJECT-123", EnvProject: "acme"}[key]
+	}
+
+	assert.Exactly(t, "feature/PROJECT-123", GetBranchNameFromEnvironment())
+	assert.Exactly(t, "acme", GetProjectNameFromEnvironment())
+}
+
+func TestFindConfigurationLayers_ExplicitConfigurationFile(t *testing.T) {
+	defer restoreProjectPathOriginals()
+	defer SetConfigurationFilePath("")
+	defer restoreWorkingDir(t)()
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	writeTestFiles(t, map[string]string{
+		".git-commit-hook.yaml": "",
+		"ci/env.json":           "",
+		"ci/flag.toml":          "",
+	})
+	getEnvFunc = func(key string) string {
+		return map[string]string{EnvConfig: "ci/env.json"}[key]
+	}
+
+	layers, err := FindConfigurationLayers()
+
+	assert.NoError(t, err)
+	assert.Exactly(t, []ConfigurationLayer{
+		{Scope: ConfigurationScopeExplicit, FilePath: path.Join(testhelper.TestPath, "ci", "env.json")},
+	}, layers)
+
+	SetConfigurationFilePath(path.Join(testhelper.TestPath, "ci", "flag.toml"))
+
+	layers, err = FindConfigurationLayers()
+
+	assert.NoError(t, err)
+	assert.Exactly(t, []ConfigurationLayer{
+		{Scope: ConfigurationScopeExplicit, FilePath: path.Join(testhelper.TestPath, "ci", "flag.toml")},
+	}, layers)
+}
+
+func TestFindConfigurationLayers_ExplicitConfigurationFileNotFound(t *testing.T) {
+	defer SetConfigurationFilePath("")
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.CleanupTestEnvironment(t)
+	SetConfigurationFilePath(path.Join(testhelper.TestPath, "missing.yaml"))
+
+	layers, err := FindConfigurationLayers()
+
+	assert.Nil(t, layers)
+	assert.EqualError(t, err, "could not find config file at '/tmp/git-commit-hook/missing.yaml': stat /tmp/git-commit-hook/missing.yaml: no such file or directory")
+}
+
+func TestLoadConfigurationLayers_ExplicitLayerIsUsedForWorkingDirRepository(t *testing.T) {
+	defer restoreWorkingDir(t)()
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	testhelper.Git(t, "init")
+	writeTestFiles(t, map[string]string{
+		"ci/git-commit-hook.yaml": "acme:\n  branch:\n    feature: \"^feature/.*$\"\n",
+	})
+	layer := ConfigurationLayer{Scope: ConfigurationScopeExplicit, FilePath: path.Join(testhelper.TestPath, "ci", "git-commit-hook.yaml")}
+
+	configuration, _, err := LoadConfigurationLayers([]ConfigurationLayer{layer})
+
+	assert.NoError(t, err)
+	assert.Exactly(t, path.Join(testhelper.TestPath, ".git"), (*configuration)["acme"].Path)
+}
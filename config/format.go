@@ -0,0 +1,253 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigurationFormat is the file format of a configuration file
+type ConfigurationFormat string
+
+const (
+	// ConfigurationFormatYAML is the format of .yaml and .yml files
+	ConfigurationFormatYAML ConfigurationFormat = "yaml"
+	// ConfigurationFormatJSON is the format of .json files
+	ConfigurationFormatJSON ConfigurationFormat = "json"
+	// ConfigurationFormatTOML is the format of .toml files
+	ConfigurationFormatTOML ConfigurationFormat = "toml"
+	// ConfigurationFormatGitConfig is the format of the commithook section of git config
+	ConfigurationFormatGitConfig ConfigurationFormat = "gitconfig"
+
+	configFileBaseName = "git-commit-hook"
+)
+
+// configFormats are the supported formats, in the order config files are searched within a location
+var configFormats = []ConfigurationFormat{ConfigurationFormatYAML, ConfigurationFormatJSON, ConfigurationFormatTOML}
+
+var lineNumberPattern = regexp.MustCompile(`line \d+: `)
+
+// GetConfigurationFormat returns the format of the given file by its extension. Files of other extensions are yaml.
+func GetConfigurationFormat(filePath string) ConfigurationFormat {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".json":
+		return ConfigurationFormatJSON
+	case ".toml":
+		return ConfigurationFormatTOML
+	default:
+		return ConfigurationFormatYAML
+	}
+}
+
+func getConfigFilenames() []string {
+	var fileNames []string
+	for _, format := range configFormats {
+		fileNames = append(fileNames, configFileBaseName+"."+string(format))
+	}
+
+	return fileNames
+}
+
+// readConfigurationFile reads the given file as yaml. Json and toml files are converted, so all formats share
+// the schema of the yaml configuration.
+func readConfigurationFile(filePath string) ([]byte, error) {
+	fileContent, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToYAML(fileContent, GetConfigurationFormat(filePath))
+}
+
+func convertToYAML(fileContent []byte, format ConfigurationFormat) ([]byte, error) {
+	var document interface{}
+	var err error
+	switch format {
+	case ConfigurationFormatJSON:
+		document, err = decodeJSON(fileContent)
+	case ConfigurationFormatTOML:
+		document, err = decodeTOML(fileContent)
+	default:
+		return fileContent, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(document)
+}
+
+// decodeJSON decodes the given json document. Objects are decoded to yaml mapping nodes to keep the order of their keys,
+// which is the order of the branch types.
+func decodeJSON(fileContent []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(fileContent))
+	decoder.UseNumber()
+
+	document, err := decodeJSONValue(decoder)
+	if err == nil && decoder.More() {
+		err = errors.New("unexpected content after the document")
+	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, fmt.Errorf("json: %v", err)
+	}
+
+	return document, nil
+}
+
+func decodeJSONValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token := token.(type) {
+	case json.Delim:
+		if token == '{' {
+			object := newMappingNode()
+			for decoder.More() {
+				key, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := decodeJSONValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+				if err := appendMappingItem(object, key, value); err != nil {
+					return nil, err
+				}
+			}
+			_, err = decoder.Token()
+
+			return object, err
+		}
+
+		array := []interface{}{}
+		for decoder.More() {
+			value, err := decodeJSONValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err = decoder.Token()
+
+		return array, err
+	case json.Number:
+		if i, err := token.Int64(); err == nil {
+			return i, nil
+		}
+		return token.Float64()
+	default:
+		return token, nil
+	}
+}
+
+// decodeTOML decodes the given toml document. Tables are decoded to yaml mapping nodes to keep the order of their keys,
+// which is the order of the branch types.
+func decodeTOML(fileContent []byte) (interface{}, error) {
+	var table map[string]interface{}
+	metaData, err := toml.Decode(string(fileContent), &table)
+	if err != nil {
+		return nil, err
+	}
+
+	keyOrder := map[string]int{}
+	for i, key := range metaData.Keys() {
+		keyOrder[joinKeyPath(key[:len(key)-1], key[len(key)-1])] = i
+	}
+
+	return orderTOMLValue(table, nil, keyOrder)
+}
+
+func orderTOMLValue(value interface{}, path []string, keyOrder map[string]int) (interface{}, error) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return keyOrder[joinKeyPath(path, keys[i])] < keyOrder[joinKeyPath(path, keys[j])]
+		})
+
+		table := newMappingNode()
+		for _, key := range keys {
+			keyPath := append(append([]string{}, path...), key)
+			item, err := orderTOMLValue(value[key], keyPath, keyOrder)
+			if err != nil {
+				return nil, err
+			}
+			if err := appendMappingItem(table, key, item); err != nil {
+				return nil, err
+			}
+		}
+		return table, nil
+	case []map[string]interface{}:
+		array := make([]interface{}, len(value))
+		for i := range value {
+			item, err := orderTOMLValue(value[i], path, keyOrder)
+			if err != nil {
+				return nil, err
+			}
+			array[i] = item
+		}
+		return array, nil
+	case []interface{}:
+		array := make([]interface{}, len(value))
+		for i := range value {
+			item, err := orderTOMLValue(value[i], path, keyOrder)
+			if err != nil {
+				return nil, err
+			}
+			array[i] = item
+		}
+		return array, nil
+	default:
+		return value, nil
+	}
+}
+
+func newMappingNode() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+// appendMappingItem appends the given key and value to the mapping node, values may be nodes themselves.
+func appendMappingItem(mapping *yaml.Node, key interface{}, value interface{}) error {
+	var keyNode, valueNode yaml.Node
+	if err := keyNode.Encode(key); err != nil {
+		return err
+	}
+	if err := valueNode.Encode(value); err != nil {
+		return err
+	}
+	mapping.Content = append(mapping.Content, &keyNode, &valueNode)
+
+	return nil
+}
+
+func joinKeyPath(path []string, key string) string {
+	return strings.Join(append(append([]string{}, path...), key), "\x00")
+}
+
+// removeLineNumbers removes the line numbers of yaml errors, which do not fit converted json and toml files.
+func removeLineNumbers(err error, format ConfigurationFormat) error {
+	if err == nil || format == ConfigurationFormatYAML {
+		return err
+	}
+
+	return fmt.Errorf("%s", lineNumberPattern.ReplaceAllString(err.Error(), ""))
+}
@@ -0,0 +1,145 @@
+package config
+
+import (
+	"path"
+	"testing"
+
+	"github.com/Oppodelldog/git-commit-hook/testhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetConfigurationFormat(t *testing.T) {
+	testDataSet := map[string]ConfigurationFormat{
+		"git-commit-hook.yaml":      ConfigurationFormatYAML,
+		"preset.yml":                ConfigurationFormatYAML,
+		"git-commit-hook.json":      ConfigurationFormatJSON,
+		"/etc/GIT-COMMIT-HOOK.JSON": ConfigurationFormatJSON,
+		"git-commit-hook.toml":      ConfigurationFormatTOML,
+		"git-commit-hook":           ConfigurationFormatYAML,
+	}
+
+	for filePath, expectedFormat := range testDataSet {
+		t.Run(filePath, func(t *testing.T) {
+			assert.Exactly(t, expectedFormat, GetConfigurationFormat(filePath))
+		})
+	}
+}
+
+func TestParse_AllFormatsShareTheSchema(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.CleanupTestEnvironment(t)
+	writeTestFiles(t, map[string]string{
+		"git-commit-hook.json": `{
+	"acme": {
+		"path": "~/work/acme/**",
+		"regexEngine": "pcre",
+		"branch": {
+			"master": "^(origin\\/)*master$",
+			"feature": "^(origin\\/)*feature/.*$",
+			"develop": "^(origin\\/)*develop$"
+		},
+		"template": {"feature": "{{.BranchName}}: {{.CommitMessage}}"},
+		"rules": {"*": {"subjectMaxLength": 72}}
+	}
+}`,
+		"git-commit-hook.toml": `
+[acme]
+path = "~/work/acme/**"
+regexEngine = "pcre"
+
+[acme.branch]
+master = '^(origin\/)*master$'
+feature = '^(origin\/)*feature/.*$'
+develop = '^(origin\/)*develop$'
+
+[acme.template]
+feature = "{{.BranchName}}: {{.CommitMessage}}"
+
+[acme.rules."*"]
+subjectMaxLength = 72
+`,
+	})
+
+	expectedConfiguration := &Configuration{
+		"acme": {
+			Path:        "~/work/acme/**",
+			RegexEngine: "pcre",
+			BranchTypes: BranchTypes{
+				{Name: "master", Pattern: `^(origin\/)*master$`},
+				{Name: "feature", Pattern: `^(origin\/)*feature/.*$`},
+				{Name: "develop", Pattern: `^(origin\/)*develop$`},
+			},
+			Templates: map[string]BranchTypeTemplate{"feature": "{{.BranchName}}: {{.CommitMessage}}"},
+			Rules:     map[string]ValidationRule{"*": {SubjectMaxLength: 72}},
+		},
+	}
+
+	for _, fileName := range []string{"git-commit-hook.json", "git-commit-hook.toml"} {
+		t.Run(fileName, func(t *testing.T) {
+			configuration, err := parse(path.Join(testhelper.TestPath, fileName))
+
+			assert.NoError(t, err)
+			assert.Exactly(t, expectedConfiguration, configuration)
+		})
+	}
+}
+
+func TestParse_ListOfBranchTypes(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.CleanupTestEnvironment(t)
+	writeTestFiles(t, map[string]string{
+		"git-commit-hook.json": `{"acme": {"branch": [
+			{"name": "hotfix", "pattern": "^hotfix/.*$", "priority": 1},
+			{"name": "any", "pattern": ".*"}
+		]}}`,
+		"git-commit-hook.toml": `
+[[acme.branch]]
+name = "hotfix"
+pattern = "^hotfix/.*$"
+priority = 1
+
+[[acme.branch]]
+name = "any"
+pattern = ".*"
+`,
+	})
+
+	for _, fileName := range []string{"git-commit-hook.json", "git-commit-hook.toml"} {
+		t.Run(fileName, func(t *testing.T) {
+			configuration, err := parse(path.Join(testhelper.TestPath, fileName))
+
+			assert.NoError(t, err)
+			assert.Exactly(t, BranchTypes{
+				{Name: "hotfix", Pattern: "^hotfix/.*$", Priority: 1},
+				{Name: "any", Pattern: ".*"},
+			}, (*configuration)["acme"].BranchTypes)
+		})
+	}
+}
+
+func TestParse_InvalidFiles(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.CleanupTestEnvironment(t)
+	writeTestFiles(t, map[string]string{
+		"unknown-key.json": `{"acme": {"brnach": {}}}`,
+		"unknown-key.toml": "[acme]\nbrnach = \"\"\n",
+		"syntax.json":      `{"acme": {"path": "~/work"`,
+		"syntax.toml":      "[acme\npath = \"~/work\"\n",
+	})
+
+	testDataSet := map[string]string{
+		"unknown-key.json": "yaml: unmarshal errors:\n  field brnach not found in type config.Project",
+		"unknown-key.toml": "yaml: unmarshal errors:\n  field brnach not found in type config.Project",
+		"syntax.json":      "json: unexpected end of JSON input",
+		"syntax.toml":      "toml: line 2: expected '.' or ']' to end table name, but got '\\n' instead",
+	}
+
+	for fileName, expectedError := range testDataSet {
+		t.Run(fileName, func(t *testing.T) {
+			configuration, err := parse(path.Join(testhelper.TestPath, fileName))
+
+			assert.Nil(t, configuration)
+			assert.EqualError(t, err, expectedError)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Oppodelldog/git-commit-hook/git"
+)
+
+const (
+	gitConfigSection    = "commithook"
+	gitConfigKeyPattern = `^commithook\.`
+	gitConfigFilePath   = "git config"
+	// gitConfigAllBranchTypes is the key that stands for all branch types (*), which is no valid git config key
+	gitConfigAllBranchTypes = "all"
+)
+
+var getGitConfigValuesFunc = git.GetConfigValues
+
+// findGitConfigLayer returns the layer of the project settings in git config, if there are any.
+func findGitConfigLayer() (ConfigurationLayer, bool) {
+	if len(getGitConfigValuesFunc(gitConfigKeyPattern)) == 0 {
+		return ConfigurationLayer{}, false
+	}
+
+	return ConfigurationLayer{Scope: ConfigurationScopeGit, FilePath: gitConfigFilePath}, true
+}
+
+// parseGitConfig reads a project from the commithook section of git config:
+//
+//	[commithook]
+//		project = acme
+//		extends = gitflow
+//	[commithook "branch"]
+//		feature = ^feature/.*$
+//	[commithook "template"]
+//		feature = {{.BranchName}}: {{.CommitMessage}}
+//
+// The project is named by commithook.project. Without it, the settings belong to the project of the given
+// configuration that matches the current repository, or to a project named like the working directory.
+// The key 'all' stands for all branch types (*), keys that are set several times add several values.
+// Git lowercases keys, so branch types defined or referred by git config have lowercase names.
+func parseGitConfig(configuration Configuration) (*Configuration, error) {
+	var projectName string
+	var project Project
+	for _, value := range getGitConfigValuesFunc(gitConfigKeyPattern) {
+		subsection, key := splitGitConfigKey(value.Key)
+		switch strings.ToLower(subsection) {
+		case "":
+			switch key {
+			case "project":
+				projectName = value.Value
+			case "path":
+				project.Path = value.Value
+			case "remote":
+				project.Remote = value.Value
+			case "extends":
+				project.Extends = append(project.Extends, splitGitConfigList(value.Value)...)
+			case "regexengine":
+				project.RegexEngine = value.Value
+			default:
+				return nil, unknownGitConfigKey(value.Key)
+			}
+		case "branch":
+			project.BranchTypes = mergeBranchTypes(project.BranchTypes, BranchTypes{{Name: key, Pattern: BranchTypePattern(value.Value)}})
+		case "template":
+			if project.Templates == nil {
+				project.Templates = map[string]BranchTypeTemplate{}
+			}
+			project.Templates[getGitConfigBranchType(key)] = BranchTypeTemplate(value.Value)
+		case "prepare":
+			if project.PrepareTemplates == nil {
+				project.PrepareTemplates = map[string]BranchTypeTemplate{}
+			}
+			project.PrepareTemplates[getGitConfigBranchType(key)] = BranchTypeTemplate(value.Value)
+		case "validation":
+			if project.Validation == nil {
+				project.Validation = map[string]BranchValidationConfiguration{}
+			}
+			branchType := getGitConfigBranchType(key)
+			if project.Validation[branchType] == nil {
+				project.Validation[branchType] = BranchValidationConfiguration{}
+			}
+			project.Validation[branchType][value.Value] = value.Value
+		case "kinds":
+			if project.MessageKinds == nil {
+				project.MessageKinds = map[string]MessageKindAction{}
+			}
+			project.MessageKinds[key] = MessageKindAction(value.Value)
+		case "nobranch":
+			switch key {
+			case "action":
+				project.NoBranch.Action = NoBranchAction(value.Value)
+			case "branchtype":
+				project.NoBranch.BranchType = value.Value
+			default:
+				return nil, unknownGitConfigKey(value.Key)
+			}
+		default:
+			return nil, unknownGitConfigKey(value.Key)
+		}
+	}
+
+	if projectName == "" {
+		projectName = getGitConfigProjectName(configuration)
+	}
+
+	return &Configuration{projectName: project}, nil
+}
+
+// getGitConfigProjectName returns the name of the project of the given configuration that matches the current
+// repository, so git config extends that project instead of defining a second one for the same repository.
+// If no project matches, the name of the working directory is returned.
+func getGitConfigProjectName(configuration Configuration) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return gitConfigSection
+	}
+
+	if projectCfg, err := findRepositoryProject(configuration, wd); err == nil {
+		return projectCfg.Name
+	}
+
+	return filepath.Base(wd)
+}
+
+// splitGitConfigKey splits a key like 'commithook.branch.feature' into subsection and key.
+// The subsection may contain dots.
+func splitGitConfigKey(gitConfigKey string) (string, string) {
+	withoutSection := strings.TrimPrefix(gitConfigKey, gitConfigSection+".")
+	i := strings.LastIndex(withoutSection, ".")
+	if i < 0 {
+		return "", withoutSection
+	}
+
+	return withoutSection[:i], withoutSection[i+1:]
+}
+
+func splitGitConfigList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+func getGitConfigBranchType(key string) string {
+	if key == gitConfigAllBranchTypes {
+		return "*"
+	}
+
+	return key
+}
+
+func unknownGitConfigKey(gitConfigKey string) error {
+	return fmt.Errorf("unknown git config key '%s'", gitConfigKey)
+}
@@ -0,0 +1,219 @@
+package config
+
+import (
+	"path"
+	"testing"
+
+	"github.com/Oppodelldog/git-commit-hook/git"
+	"github.com/Oppodelldog/git-commit-hook/testhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGitConfig(t *testing.T) {
+	defer restoreGetGitConfigValuesFunc(getGitConfigValuesFunc)
+	getGitConfigValuesFunc = func(keyPattern string) []git.ConfigValue {
+		assert.Exactly(t, `^commithook\.`, keyPattern)
+		return []git.ConfigValue{
+			{Key: "commithook.project", Value: "acme"},
+			{Key: "commithook.remote", Value: "git@github.com:acme/*"},
+			{Key: "commithook.extends", Value: "gitflow, jira"},
+			{Key: "commithook.extends", Value: "conventional"},
+			{Key: "commithook.regexengine", Value: "pcre"},
+			{Key: "commithook.branch.feature", Value: "^feature/.*$"},
+			{Key: "commithook.branch.release", Value: "^release/.*$"},
+			{Key: "commithook.branch.feature", Value: "^feat/.*$"},
+			{Key: "commithook.Template.feature", Value: "{{.BranchName}}: {{.CommitMessage}}"},
+			{Key: "commithook.prepare.all", Value: "{{.Branch.ticket}}: "},
+			{Key: "commithook.validation.release", Value: "(?m)@rc-fix"},
+			{Key: "commithook.validation.release", Value: "(?m)@noissue"},
+			{Key: "commithook.kinds.merge", Value: "skip"},
+			{Key: "commithook.noBranch.action", Value: "fallback"},
+			{Key: "commithook.noBranch.branchtype", Value: "feature"},
+		}
+	}
+
+	configuration, err := parseGitConfig(Configuration{})
+
+	assert.NoError(t, err)
+	assert.Exactly(t, &Configuration{
+		"acme": {
+			Remote:      "git@github.com:acme/*",
+			Extends:     []string{"gitflow", "jira", "conventional"},
+			RegexEngine: "pcre",
+			BranchTypes: BranchTypes{
+				{Name: "feature", Pattern: "^feat/.*$"},
+				{Name: "release", Pattern: "^release/.*$"},
+			},
+			Templates:        map[string]BranchTypeTemplate{"feature": "{{.BranchName}}: {{.CommitMessage}}"},
+			PrepareTemplates: map[string]BranchTypeTemplate{"*": "{{.Branch.ticket}}: "},
+			Validation: map[string]BranchValidationConfiguration{
+				"release": {"(?m)@rc-fix": "(?m)@rc-fix", "(?m)@noissue": "(?m)@noissue"},
+			},
+			MessageKinds: map[string]MessageKindAction{"merge": MessageKindActionSkip},
+			NoBranch:     NoBranchConfiguration{Action: NoBranchActionFallback, BranchType: "feature"},
+		},
+	}, configuration)
+}
+
+func TestParseGitConfig_ProjectNameDefaultsToWorkingDirName(t *testing.T) {
+	defer restoreGetGitConfigValuesFunc(getGitConfigValuesFunc)
+	defer restoreWorkingDir(t)()
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	getGitConfigValuesFunc = func(string) []git.ConfigValue {
+		return []git.ConfigValue{{Key: "commithook.branch.feature", Value: "^feature/.*$"}}
+	}
+
+	configuration, err := parseGitConfig(Configuration{})
+
+	assert.NoError(t, err)
+	assert.Exactly(t, &Configuration{
+		path.Base(testhelper.TestPath): {BranchTypes: BranchTypes{{Name: "feature", Pattern: "^feature/.*$"}}},
+	}, configuration)
+}
+
+func TestParseGitConfig_UnknownKey(t *testing.T) {
+	defer restoreGetGitConfigValuesFunc(getGitConfigValuesFunc)
+
+	for _, key := range []string{"commithook.pth", "commithook.tempalte.feature", "commithook.nobranch.type"} {
+		t.Run(key, func(t *testing.T) {
+			getGitConfigValuesFunc = func(string) []git.ConfigValue {
+				return []git.ConfigValue{{Key: key, Value: "x"}}
+			}
+
+			configuration, err := parseGitConfig(Configuration{})
+
+			assert.Nil(t, configuration)
+			assert.EqualError(t, err, "unknown git config key '"+key+"'")
+		})
+	}
+}
+
+func TestLoadConfigurationLayers_GitConfigWithoutConfigurationFile(t *testing.T) {
+	defer restoreSystemConfigDir(systemConfigDir)
+	defer restoreWorkingDir(t)()
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	testhelper.Git(t, "init")
+	testhelper.Git(t, "config", "commithook.project", "acme")
+	testhelper.Git(t, "config", "commithook.branch.feature", "^feature/.*$")
+	testhelper.Git(t, "config", "commithook.template.feature", "{{.BranchName}}: {{.CommitMessage}}")
+	systemConfigDir = path.Join(testhelper.TestPath, "etc")
+
+	layers, err := FindConfigurationLayers()
+	assert.NoError(t, err)
+	assert.Exactly(t, []ConfigurationLayer{{Scope: ConfigurationScopeGit, FilePath: "git config"}}, withoutUserLayer(layers))
+	assert.Exactly(t, ConfigurationFormatGitConfig, layers[len(layers)-1].Format())
+
+	configuration, _, err := LoadConfigurationLayers(withoutUserLayer(layers))
+
+	assert.NoError(t, err)
+	assert.Exactly(t, &Configuration{
+		"acme": {
+			Path:        path.Join(testhelper.TestPath, ".git"),
+			BranchTypes: BranchTypes{{Name: "feature", Pattern: "^feature/.*$"}},
+			Templates:   map[string]BranchTypeTemplate{"feature": "{{.BranchName}}: {{.CommitMessage}}"},
+		},
+	}, configuration)
+}
+
+func TestLoadConfigurationLayers_GitConfigOverridesConfigurationFiles(t *testing.T) {
+	defer restoreGetGitConfigValuesFunc(getGitConfigValuesFunc)
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.CleanupTestEnvironment(t)
+	writeTestFiles(t, map[string]string{
+		"git-commit-hook.yaml": `
+acme:
+  path: /home/dev/acme/.git
+  branch:
+    - name: feature
+      pattern: "^feature/.*$"
+  template:
+    feature: "{{.CommitMessage}}"
+`,
+	})
+	getGitConfigValuesFunc = func(string) []git.ConfigValue {
+		return []git.ConfigValue{
+			{Key: "commithook.project", Value: "acme"},
+			{Key: "commithook.template.feature", Value: "{{.BranchName}}: {{.CommitMessage}}"},
+		}
+	}
+	userLayer := ConfigurationLayer{Scope: ConfigurationScopeUser, FilePath: path.Join(testhelper.TestPath, "git-commit-hook.yaml")}
+	gitLayer := ConfigurationLayer{Scope: ConfigurationScopeGit, FilePath: "git config"}
+
+	configuration, origins, err := LoadConfigurationLayers([]ConfigurationLayer{userLayer, gitLayer})
+
+	assert.NoError(t, err)
+	assert.Exactly(t, &Configuration{
+		"acme": {
+			Path:        "/home/dev/acme/.git",
+			BranchTypes: BranchTypes{{Name: "feature", Pattern: "^feature/.*$"}},
+			Templates:   map[string]BranchTypeTemplate{"feature": "{{.BranchName}}: {{.CommitMessage}}"},
+		},
+	}, configuration)
+	layer, _ := origins.Get("acme", "template", "feature")
+	assert.Exactly(t, gitLayer, layer)
+}
+
+func TestLoadConfigurationLayers_GitConfigWithoutProjectNameExtendsProjectOfRepository(t *testing.T) {
+	defer restoreGetGitConfigValuesFunc(getGitConfigValuesFunc)
+	defer restoreWorkingDir(t)()
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	testhelper.Git(t, "init")
+	gitDir := path.Join(testhelper.TestPath, ".git")
+	writeTestFiles(t, map[string]string{
+		"user.yaml": `
+acme:
+  path: ` + gitDir + `
+  branch:
+    - name: feature
+      pattern: "^feature/.*$"
+  template:
+    feature: "{{.CommitMessage}}"
+`,
+	})
+	getGitConfigValuesFunc = func(string) []git.ConfigValue {
+		return []git.ConfigValue{
+			{Key: "commithook.template.feature", Value: "{{.BranchName}}: {{.CommitMessage}}"},
+		}
+	}
+	userLayer := ConfigurationLayer{Scope: ConfigurationScopeUser, FilePath: path.Join(testhelper.TestPath, "user.yaml")}
+	gitLayer := ConfigurationLayer{Scope: ConfigurationScopeGit, FilePath: "git config"}
+
+	configuration, origins, err := LoadConfigurationLayers([]ConfigurationLayer{userLayer, gitLayer})
+
+	assert.NoError(t, err)
+	assert.Exactly(t, &Configuration{
+		"acme": {
+			Path:        gitDir,
+			BranchTypes: BranchTypes{{Name: "feature", Pattern: "^feature/.*$"}},
+			Templates:   map[string]BranchTypeTemplate{"feature": "{{.BranchName}}: {{.CommitMessage}}"},
+		},
+	}, configuration)
+	layer, _ := origins.Get("acme", "template", "feature")
+	assert.Exactly(t, gitLayer, layer)
+}
+
+func TestLintConfigurationLayers_GitConfig(t *testing.T) {
+	defer restoreGetGitConfigValuesFunc(getGitConfigValuesFunc)
+	getGitConfigValuesFunc = func(string) []git.ConfigValue {
+		return []git.ConfigValue{
+			{Key: "commithook.project", Value: "acme"},
+			{Key: "commithook.branch.feature", Value: "^feature/(.*$"},
+			{Key: "commithook.template.featrue", Value: "{{.CommitMessage}}"},
+		}
+	}
+	gitLayer := ConfigurationLayer{Scope: ConfigurationScopeGit, FilePath: "git config"}
+
+	lintErrors := LintConfigurationLayers([]ConfigurationLayer{gitLayer})
+
+	assert.Exactly(t, []LintError{
+		{FilePath: "git config", Message: "invalid pattern '^feature/(.*$': error parsing regexp: missing closing ): `^feature/(.*$`"},
+		{FilePath: "git config", Message: "unknown branch type 'featrue'"},
+	}, lintErrors)
+}
+
+func restoreGetGitConfigValuesFunc(f func(string) []git.ConfigValue) {
+	getGitConfigValuesFunc = f
+}
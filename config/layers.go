@@ -0,0 +1,275 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Oppodelldog/filediscovery"
+	"github.com/Oppodelldog/git-commit-hook/git"
+)
+
+// ConfigurationScope names a layer of the configuration
+type ConfigurationScope string
+
+const (
+	// ConfigurationScopeSystem is the machine wide configuration
+	ConfigurationScopeSystem ConfigurationScope = "system"
+	// ConfigurationScopeUser is the configuration of the current user
+	ConfigurationScopeUser ConfigurationScope = "user"
+	// ConfigurationScopeRepository is the configuration committed to the repository
+	ConfigurationScopeRepository ConfigurationScope = "repository"
+	// ConfigurationScopeLocal is the untracked configuration of the repository
+	ConfigurationScopeLocal ConfigurationScope = "local"
+	// ConfigurationScopeGit is the commithook section of git config, it is merged after all configuration files
+	ConfigurationScopeGit ConfigurationScope = "git"
+	// ConfigurationScopeExplicit is the configuration file given by --config or GIT_COMMIT_HOOK_CONFIG
+	ConfigurationScopeExplicit ConfigurationScope = "explicit"
+)
+
+var systemConfigDir = "/etc/git-commit-hook"
+
+type (
+	// ConfigurationLayer is a configuration file that is merged into the configuration.
+	ConfigurationLayer struct {
+		Scope    ConfigurationScope
+		FilePath string
+	}
+
+	// ConfigurationOrigins tells for every configured value the layer it was taken from.
+	ConfigurationOrigins map[string]ConfigurationLayer
+
+	configurationLayerDefinition struct {
+		scope     ConfigurationScope
+		providers []filediscovery.FileLocationProvider
+	}
+)
+
+// Get returns the layer the value of the given project was taken from. The section is the name of the value in the
+// config file, like 'path' or 'template', key is the key within the section, like the branch type of a template.
+func (o ConfigurationOrigins) Get(projectName string, section string, key string) (ConfigurationLayer, bool) {
+	layer, ok := o[originKey(projectName, section, key)]
+
+	return layer, ok
+}
+
+// Set records the layer the value of the given project was taken from. Nothing is recorded on nil origins.
+func (o ConfigurationOrigins) Set(projectName string, section string, key string, layer ConfigurationLayer) {
+	if o == nil {
+		return
+	}
+	o[originKey(projectName, section, key)] = layer
+}
+
+// Format returns the file format of the layer
+func (l ConfigurationLayer) Format() ConfigurationFormat {
+	if l.Scope == ConfigurationScopeGit {
+		return ConfigurationFormatGitConfig
+	}
+
+	return GetConfigurationFormat(l.FilePath)
+}
+
+func originKey(projectName string, section string, key string) string {
+	return projectName + "\x00" + section + "\x00" + key
+}
+
+// getConfigurationLayerDefinitions returns the places configuration files are searched at, ordered by ascending
+// precedence. Within a scope the first file found is used, every location is searched for a yaml, json and toml file.
+func getConfigurationLayerDefinitions() []configurationLayerDefinition {
+	return []configurationLayerDefinition{
+		{
+			scope:     ConfigurationScopeSystem,
+			providers: []filediscovery.FileLocationProvider{dirProvider(systemConfigDir)},
+		},
+		{
+			scope: ConfigurationScopeUser,
+			providers: []filediscovery.FileLocationProvider{
+				filediscovery.ExecutableDirProvider(),
+				filediscovery.HomeConfigDirProvider(".config", "git-commit-hook"),
+			},
+		},
+		{
+			scope: ConfigurationScopeRepository,
+			providers: []filediscovery.FileLocationProvider{
+				hiddenFileProvider(filediscovery.WorkingDirProvider()),
+				filediscovery.WorkingDirProvider(),
+			},
+		},
+		{
+			scope: ConfigurationScopeLocal,
+			providers: []filediscovery.FileLocationProvider{
+				filediscovery.WorkingDirProvider(".git", "hooks"),
+				filediscovery.WorkingDirProvider(".git"),
+			},
+		},
+	}
+}
+
+// FindConfigurationLayers searches the git-commit-hook config files of all scopes and returns the found ones
+// ordered by ascending precedence. If the commithook section of git config is set, it is the last layer.
+// If no layer was found at all, an error is returned.
+// A configuration file given by --config or GIT_COMMIT_HOOK_CONFIG replaces all other layers.
+func FindConfigurationLayers() ([]ConfigurationLayer, error) {
+	if filePath := getExplicitConfigurationFilePath(); filePath != "" {
+		return findExplicitConfigurationLayer(filePath)
+	}
+
+	var layers []ConfigurationLayer
+	errorString := bytes.NewBufferString("")
+	for _, definition := range getConfigurationLayerDefinitions() {
+		filePath, err := discoverConfigurationFile(definition.providers)
+		if err != nil {
+			errorString.WriteString(err.Error())
+			continue
+		}
+		layers = append(layers, ConfigurationLayer{Scope: definition.scope, FilePath: filePath})
+	}
+
+	if layer, ok := findGitConfigLayer(); ok {
+		layers = append(layers, layer)
+	}
+
+	if len(layers) == 0 {
+		return nil, errors.New(errorString.String())
+	}
+
+	return layers, nil
+}
+
+// LoadConfigurationLayers parses the given layers and merges them in the given order, so values of later layers
+// override those of former ones. Projects of the repository, local, git and explicit layer that have neither path
+// nor remote and are not extended by other projects are used for the repository of the working directory.
+// Finally the presets the projects extend are resolved.
+func LoadConfigurationLayers(layers []ConfigurationLayer) (*Configuration, ConfigurationOrigins, error) {
+	configuration, origins, err := mergeConfigurationLayers(layers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = resolveExtends(configuration)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &configuration, origins, nil
+}
+
+func mergeConfigurationLayers(layers []ConfigurationLayer) (Configuration, ConfigurationOrigins, error) {
+	configuration := Configuration{}
+	origins := ConfigurationOrigins{}
+	for _, layer := range layers {
+		layerConfiguration, err := parseLayer(layer, configuration)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if isRepositoryScope(layer.Scope) {
+			assignWorkingDirRepository(configuration, *layerConfiguration)
+		}
+
+		for projectName, projectCfg := range *layerConfiguration {
+			resolvePresetFilePaths(&projectCfg, filepath.Dir(layer.FilePath))
+			(*layerConfiguration)[projectName] = projectCfg
+		}
+
+		mergeConfiguration(configuration, *layerConfiguration, layer, origins)
+	}
+
+	return configuration, origins, nil
+}
+
+func findExplicitConfigurationLayer(filePath string) ([]ConfigurationLayer, error) {
+	absFilePath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(absFilePath); err != nil {
+		return nil, fmt.Errorf("could not find config file at '%s': %v", absFilePath, err)
+	}
+
+	return []ConfigurationLayer{{Scope: ConfigurationScopeExplicit, FilePath: absFilePath}}, nil
+}
+
+// parseLayer parses the given layer, the project of git config is named by the given configuration of the former
+// layers, see parseGitConfig.
+func parseLayer(layer ConfigurationLayer, configuration Configuration) (*Configuration, error) {
+	if layer.Scope == ConfigurationScopeGit {
+		return parseGitConfig(configuration)
+	}
+
+	return parse(layer.FilePath)
+}
+
+// isRepositoryScope tells whether projects of the given scope may be used for the repository of the working directory
+func isRepositoryScope(scope ConfigurationScope) bool {
+	switch scope {
+	case ConfigurationScopeRepository, ConfigurationScopeLocal, ConfigurationScopeGit, ConfigurationScopeExplicit:
+		return true
+	default:
+		return false
+	}
+}
+
+func assignWorkingDirRepository(configuration Configuration, layerConfiguration Configuration) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return
+	}
+	gitDir, err := git.FindGitDir(wd)
+	if err != nil {
+		return
+	}
+
+	extendedProjectNames := map[string]bool{}
+	for _, c := range []Configuration{configuration, layerConfiguration} {
+		for _, projectCfg := range c {
+			for _, presetName := range projectCfg.Extends {
+				extendedProjectNames[presetName] = true
+			}
+		}
+	}
+
+	for projectName, projectCfg := range layerConfiguration {
+		if projectCfg.Path != "" || projectCfg.Remote != "" || extendedProjectNames[projectName] {
+			continue
+		}
+		if existing, ok := configuration[projectName]; ok && (existing.Path != "" || existing.Remote != "") {
+			continue
+		}
+		projectCfg.Path = gitDir
+		layerConfiguration[projectName] = projectCfg
+	}
+}
+
+func dirProvider(dir string) filediscovery.FileLocationProvider {
+	return func(fileName string) (string, error) {
+		return filepath.Join(dir, fileName), nil
+	}
+}
+
+// discoverConfigurationFile returns the first config file found. The locations are searched in the given order,
+// each location for all supported formats.
+func discoverConfigurationFile(providers []filediscovery.FileLocationProvider) (string, error) {
+	errorString := bytes.NewBufferString("")
+	for _, provider := range providers {
+		for _, fileName := range getConfigFilenames() {
+			filePath, err := filediscovery.New([]filediscovery.FileLocationProvider{provider}).Discover(fileName)
+			if err == nil {
+				return filePath, nil
+			}
+			errorString.WriteString(err.Error())
+		}
+	}
+
+	return "", errors.New(errorString.String())
+}
+
+// hiddenFileProvider lets the given provider search for the hidden file of the given file name.
+func hiddenFileProvider(provider filediscovery.FileLocationProvider) filediscovery.FileLocationProvider {
+	return func(fileName string) (string, error) {
+		return provider("." + fileName)
+	}
+}
@@ -0,0 +1,198 @@
+package config
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Oppodelldog/git-commit-hook/testhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindConfigurationLayers(t *testing.T) {
+	defer restoreSystemConfigDir(systemConfigDir)
+	defer restoreWorkingDir(t)()
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	systemConfigDir = path.Join(testhelper.TestPath, "etc")
+	writeTestFiles(t, map[string]string{
+		"etc/git-commit-hook.yaml":        "",
+		".git-commit-hook.yaml":           "",
+		"git-commit-hook.yaml":            "",
+		".git/git-commit-hook.yaml":       "",
+		".git/hooks/git-commit-hook.yaml": "",
+	})
+
+	layers, err := FindConfigurationLayers()
+
+	assert.NoError(t, err)
+	assert.Exactly(t, []ConfigurationLayer{
+		{Scope: ConfigurationScopeSystem, FilePath: path.Join(testhelper.TestPath, "etc", "git-commit-hook.yaml")},
+		{Scope: ConfigurationScopeRepository, FilePath: path.Join(testhelper.TestPath, ".git-commit-hook.yaml")},
+		{Scope: ConfigurationScopeLocal, FilePath: path.Join(testhelper.TestPath, ".git", "hooks", "git-commit-hook.yaml")},
+	}, withoutUserLayer(layers))
+}
+
+func TestFindConfigurationLayers_LegacyFileNameInWorkingDirIsRepositoryLayer(t *testing.T) {
+	defer restoreSystemConfigDir(systemConfigDir)
+	defer restoreWorkingDir(t)()
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	systemConfigDir = path.Join(testhelper.TestPath, "etc")
+	writeTestFiles(t, map[string]string{"git-commit-hook.yaml": ""})
+
+	layers, err := FindConfigurationLayers()
+
+	assert.NoError(t, err)
+	assert.Exactly(t, []ConfigurationLayer{
+		{Scope: ConfigurationScopeRepository, FilePath: path.Join(testhelper.TestPath, "git-commit-hook.yaml")},
+	}, withoutUserLayer(layers))
+}
+
+func TestFindConfigurationLayers_JSONAndTOMLFiles(t *testing.T) {
+	defer restoreSystemConfigDir(systemConfigDir)
+	defer restoreWorkingDir(t)()
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	systemConfigDir = path.Join(testhelper.TestPath, "etc")
+	writeTestFiles(t, map[string]string{
+		"etc/git-commit-hook.toml":  "",
+		".git-commit-hook.json":     "",
+		"git-commit-hook.yaml":      "",
+		".git/git-commit-hook.yaml": "",
+		".git/git-commit-hook.toml": "",
+	})
+
+	layers, err := FindConfigurationLayers()
+
+	assert.NoError(t, err)
+	assert.Exactly(t, []ConfigurationLayer{
+		{Scope: ConfigurationScopeSystem, FilePath: path.Join(testhelper.TestPath, "etc", "git-commit-hook.toml")},
+		{Scope: ConfigurationScopeRepository, FilePath: path.Join(testhelper.TestPath, ".git-commit-hook.json")},
+		{Scope: ConfigurationScopeLocal, FilePath: path.Join(testhelper.TestPath, ".git", "git-commit-hook.yaml")},
+	}, withoutUserLayer(layers))
+	assert.Exactly(t, ConfigurationFormatTOML, layers[0].Format())
+}
+
+func TestLoadConfigurationLayers_MergesLayers(t *testing.T) {
+	defer restoreWorkingDir(t)()
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	testhelper.Git(t, "init")
+	writeTestFiles(t, map[string]string{
+		"user.yaml": `
+acme:
+  path: "~/work/acme/**"
+  regexEngine: pcre
+  branch:
+    - name: feature
+      pattern: "^feature/.*$"
+    - name: release
+      pattern: "^release/.*$"
+  template:
+    feature: "{{.BranchName}}: {{.CommitMessage}}"
+    release: "release: {{.CommitMessage}}"
+`,
+		"repository.yaml": `
+acme:
+  branch:
+    - name: feature
+      pattern: "^feat/.*$"
+    - name: hotfix
+      pattern: "^hotfix/.*$"
+  template:
+    feature: "{{.CommitMessage}}"
+team:
+  extends: [base]
+  template:
+    "*": "{{.CommitMessage}}"
+base:
+  template:
+    release: "{{.CommitMessage}}"
+`,
+	})
+	userLayer := ConfigurationLayer{Scope: ConfigurationScopeUser, FilePath: path.Join(testhelper.TestPath, "user.yaml")}
+	repositoryLayer := ConfigurationLayer{Scope: ConfigurationScopeRepository, FilePath: path.Join(testhelper.TestPath, "repository.yaml")}
+
+	configuration, origins, err := LoadConfigurationLayers([]ConfigurationLayer{userLayer, repositoryLayer})
+
+	assert.NoError(t, err)
+	assert.Exactly(t, &Configuration{
+		"acme": {
+			Path:        "~/work/acme/**",
+			RegexEngine: "pcre",
+			BranchTypes: BranchTypes{
+				{Name: "feature", Pattern: "^feat/.*$"},
+				{Name: "release", Pattern: "^release/.*$"},
+				{Name: "hotfix", Pattern: "^hotfix/.*$"},
+			},
+			Templates: map[string]BranchTypeTemplate{
+				"feature": "{{.CommitMessage}}",
+				"release": "release: {{.CommitMessage}}",
+			},
+		},
+		"team": {
+			Path:      path.Join(testhelper.TestPath, ".git"),
+			Extends:   []string{"base"},
+			Templates: map[string]BranchTypeTemplate{"*": "{{.CommitMessage}}", "release": "{{.CommitMessage}}"},
+		},
+		"base": {
+			Templates: map[string]BranchTypeTemplate{"release": "{{.CommitMessage}}"},
+		},
+	}, configuration)
+
+	for _, testData := range []struct {
+		projectName   string
+		section       string
+		key           string
+		expectedLayer ConfigurationLayer
+	}{
+		{projectName: "acme", section: "path", expectedLayer: userLayer},
+		{projectName: "acme", section: "regexEngine", expectedLayer: userLayer},
+		{projectName: "acme", section: "branch", key: "feature", expectedLayer: repositoryLayer},
+		{projectName: "acme", section: "branch", key: "release", expectedLayer: userLayer},
+		{projectName: "acme", section: "template", key: "feature", expectedLayer: repositoryLayer},
+		{projectName: "acme", section: "template", key: "release", expectedLayer: userLayer},
+		{projectName: "team", section: "template", key: "*", expectedLayer: repositoryLayer},
+	} {
+		layer, ok := origins.Get(testData.projectName, testData.section, testData.key)
+		assert.True(t, ok)
+		assert.Exactly(t, testData.expectedLayer, layer, "%s %s %s", testData.projectName, testData.section, testData.key)
+	}
+}
+
+func TestLoadConfigurationLayers_InvalidLayer(t *testing.T) {
+	_, _, err := LoadConfigurationLayers([]ConfigurationLayer{{Scope: ConfigurationScopeUser, FilePath: "test-data-invalid.yaml"}})
+
+	assert.Error(t, err)
+}
+
+func withoutUserLayer(layers []ConfigurationLayer) []ConfigurationLayer {
+	var filtered []ConfigurationLayer
+	for _, layer := range layers {
+		if layer.Scope != ConfigurationScopeUser {
+			filtered = append(filtered, layer)
+		}
+	}
+
+	return filtered
+}
+
+// restoreWorkingDir returns a func that changes back to the current working dir
+func restoreWorkingDir(t *testing.T) func() {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Did not expect os.Getwd to return an error, but got: %v ", err)
+	}
+
+	return func() {
+		if err := os.Chdir(wd); err != nil {
+			t.Fatalf("Did not expect os.Chdir to return an error, but got: %v ", err)
+		}
+	}
+}
+
+func restoreSystemConfigDir(dir string) {
+	systemConfigDir = dir
+}
@@ -0,0 +1,624 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/Oppodelldog/git-commit-hook/regexadapter"
+	"github.com/Oppodelldog/git-commit-hook/templatefuncs"
+	"gopkg.in/yaml.v3"
+)
+
+// LintError is a problem found in a configuration file, it points to the line and column of the problem.
+// Problems of json and toml files and of git config have no position, since those are linted after converting them
+// to yaml.
+type LintError struct {
+	FilePath string
+	Line     int
+	Column   int
+	Message  string
+}
+
+func (e LintError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %s", e.FilePath, e.Message)
+	}
+
+	return fmt.Sprintf("%s:%d:%d: %s", e.FilePath, e.Line, e.Column, e.Message)
+}
+
+var (
+	// lintMessageKinds are the kinds of commit messages that can be configured in the kinds section
+	lintMessageKinds   = []string{"merge", "squash", "fixup", "revert"}
+	lintKindActions    = []string{string(MessageKindActionDefault), string(MessageKindActionSkip), string(MessageKindActionTemplate), string(MessageKindActionValidate)}
+	lintNoBranchAction = []string{string(NoBranchActionSkip), string(NoBranchActionFallback), string(NoBranchActionFail)}
+	yamlSyntaxError    = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+	// lintTrailerKey matches the keys git accepts for trailers
+	lintTrailerKey = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*$`)
+	// decodeErrorLine, unknownFieldError and unmarshalValueError match the errors of the yaml decoder
+	decodeErrorLine     = regexp.MustCompile(`^line (\d+): (.*)$`)
+	unknownFieldError   = regexp.MustCompile(`^field (\S+) not found in type (\S+)$`)
+	unmarshalValueError = regexp.MustCompile("^cannot unmarshal \\S+ `(.*)` into ")
+)
+
+// LintConfigurationLayers strictly checks the given configuration files. It reports unknown keys, values of the
+// wrong type, patterns and templates that do not compile, references to branch types that do not exist and
+// unknown presets. Branch types and presets may be defined in any of the layers.
+// Files are decoded like they are loaded, problems are located by the yaml nodes of the file.
+func LintConfigurationLayers(layers []ConfigurationLayer) []LintError {
+	// projects are merged leniently, so the problems of one file do not hide the presets and branch types it defines
+	configuration := Configuration{}
+	for _, layer := range layers {
+		fileContent, err := readLintLayer(layer, configuration)
+		if err != nil {
+			continue
+		}
+		var layerConfiguration Configuration
+		if err := decodeStrict(fileContent, &layerConfiguration); err != nil {
+			// unknown keys and values of the wrong type are reported by the linter, everything else is usable
+			if _, isTypeError := err.(*yaml.TypeError); !isTypeError {
+				continue
+			}
+		}
+		for projectName, projectCfg := range layerConfiguration {
+			resolvePresetFilePaths(&projectCfg, filepath.Dir(layer.FilePath))
+			layerConfiguration[projectName] = projectCfg
+		}
+		mergeConfiguration(configuration, layerConfiguration, layer, nil)
+	}
+
+	var lintErrors []LintError
+	for _, layer := range layers {
+		lintErrors = append(lintErrors, lintConfigurationLayer(layer, configuration)...)
+	}
+
+	return lintErrors
+}
+
+// readLintLayer returns the content of the given layer as yaml, the project of git config is converted to yaml.
+// It is named by the given configuration, see parseGitConfig.
+func readLintLayer(layer ConfigurationLayer, configuration Configuration) ([]byte, error) {
+	if layer.Scope == ConfigurationScopeGit {
+		gitConfiguration, err := parseGitConfig(configuration)
+		if err != nil {
+			return nil, err
+		}
+		return yaml.Marshal(gitConfiguration)
+	}
+
+	return readConfigurationFile(layer.FilePath)
+}
+
+type linter struct {
+	filePath      string
+	format        ConfigurationFormat
+	configuration Configuration
+	root          *yaml.Node
+	regexEngine   string
+	errors        []LintError
+}
+
+// lintConfigurationLayer checks the given layer, projects and presets are resolved by the given configuration.
+func lintConfigurationLayer(layer ConfigurationLayer, configuration Configuration) []LintError {
+	filePath := layer.FilePath
+	l := &linter{filePath: filePath, format: layer.Format(), configuration: configuration}
+
+	fileContent, err := readLintLayer(layer, configuration)
+	if err != nil {
+		return []LintError{{FilePath: filePath, Message: err.Error()}}
+	}
+
+	var document yaml.Node
+	err = yaml.Unmarshal(fileContent, &document)
+	if err != nil {
+		lintError := LintError{FilePath: filePath, Message: err.Error()}
+		if matches := yamlSyntaxError.FindStringSubmatch(err.Error()); matches != nil {
+			lintError.Line, _ = strconv.Atoi(matches[1])
+			lintError.Message = matches[2]
+		}
+		return []LintError{lintError}
+	}
+
+	if len(document.Content) == 0 {
+		return nil
+	}
+	l.root = document.Content[0]
+
+	var layerConfiguration Configuration
+	l.addDecodeErrors(decodeStrict(fileContent, &layerConfiguration))
+
+	if l.root.Kind == yaml.MappingNode {
+		for i := 0; i < len(l.root.Content); i += 2 {
+			projectName := l.root.Content[i].Value
+			if project, ok := layerConfiguration[projectName]; ok {
+				l.lintProject(projectName, project)
+			}
+		}
+	}
+
+	sort.SliceStable(l.errors, func(i, j int) bool {
+		a, b := l.errors[i], l.errors[j]
+		return a.Line < b.Line || a.Line == b.Line && a.Column < b.Column
+	})
+
+	return l.errors
+}
+
+// addDecodeErrors reports the errors of decoding the file, like unknown keys or values of the wrong type, at the
+// node they refer to.
+func (l *linter) addDecodeErrors(err error) {
+	if err == nil {
+		return
+	}
+
+	typeError, ok := err.(*yaml.TypeError)
+	if !ok {
+		l.errors = append(l.errors, LintError{FilePath: l.filePath, Message: err.Error()})
+		return
+	}
+
+	for _, message := range typeError.Errors {
+		line := 0
+		if matches := decodeErrorLine.FindStringSubmatch(message); matches != nil {
+			line, _ = strconv.Atoi(matches[1])
+			message = matches[2]
+		}
+
+		if matches := unknownFieldError.FindStringSubmatch(message); matches != nil {
+			l.addError(findNodeOnLine(l.root, line, matches[1]), unknownKeyMessage(matches[1], matches[2]))
+			continue
+		}
+
+		value := ""
+		if matches := unmarshalValueError.FindStringSubmatch(message); matches != nil {
+			value = matches[1]
+		}
+		l.addError(findNodeOnLine(l.root, line, value), message)
+	}
+}
+
+func (l *linter) lintProject(projectName string, project Project) {
+	errorCount := len(l.errors)
+	l.lintExtends(projectName, project.Extends)
+	resolvedProject, resolved := l.resolveProject(projectName, len(project.Extends) > 0 && len(l.errors) == errorCount)
+	var branchTypeNames map[string]bool
+	if resolved {
+		branchTypeNames = getBranchTypeNames(resolvedProject)
+	}
+	l.regexEngine = getRegexEngine(resolvedProject)
+
+	if project.RegexEngine != "" {
+		l.expectOneOf(l.nodeAt(projectName, "regexEngine"), "regex engine", project.RegexEngine, regexadapter.Engines())
+	}
+
+	l.lintBranchTypes(projectName, project.BranchTypes)
+
+	l.forEachBranchType(projectName, "template", project.Templates, branchTypeNames, func(path []interface{}, branchType string) {
+		l.lintTemplate(l.nodeAt(path...), string(project.Templates[branchType]))
+	})
+	l.forEachBranchType(projectName, "prepare", project.PrepareTemplates, branchTypeNames, func(path []interface{}, branchType string) {
+		l.lintTemplate(l.nodeAt(path...), string(project.PrepareTemplates[branchType]))
+	})
+	l.forEachBranchType(projectName, "validation", project.Validation, branchTypeNames, func(path []interface{}, branchType string) {
+		for _, pattern := range sortedKeys(project.Validation[branchType]) {
+			l.lintPattern(l.keyNodeAt(appendPath(path, pattern)...), pattern)
+		}
+	})
+	l.forEachBranchType(projectName, "rules", project.Rules, branchTypeNames, func(path []interface{}, branchType string) {
+		l.lintValidationRule(path, project.Rules[branchType])
+	})
+	// the values of conventional are checked by the decoder
+	l.forEachBranchType(projectName, "conventional", project.Conventional, branchTypeNames, func([]interface{}, string) {})
+	l.forEachBranchType(projectName, "trailers", project.Trailers, branchTypeNames, func(path []interface{}, branchType string) {
+		l.lintTrailers(path, project.Trailers[branchType])
+	})
+
+	for _, messageKind := range sortedKeys(project.MessageKinds) {
+		l.expectOneOf(l.keyNodeAt(projectName, "kinds", messageKind), "message kind", messageKind, lintMessageKinds)
+		l.expectOneOf(l.nodeAt(projectName, "kinds", messageKind), "action", string(project.MessageKinds[messageKind]), lintKindActions)
+	}
+
+	l.lintNoBranch(projectName, project.NoBranch, branchTypeNames)
+}
+
+// resolveProject returns the project with the presets it extends merged into it. If the presets cannot be resolved,
+// false is returned and the error is reported at the extends key, if reportError is true.
+func (l *linter) resolveProject(projectName string, reportError bool) (Project, bool) {
+	if _, ok := l.configuration[projectName]; !ok {
+		return Project{}, false
+	}
+
+	resolver := presetResolver{configuration: l.configuration, resolved: map[string]Project{}}
+	project, err := resolver.resolve(projectName, nil)
+	if err != nil {
+		if reportError {
+			l.addError(l.nodeAt(projectName, "extends"), err.Error())
+		}
+		return Project{}, false
+	}
+
+	return project, true
+}
+
+// getBranchTypeNames returns the names of all branch types of the given project.
+func getBranchTypeNames(project Project) map[string]bool {
+	branchTypeNames := map[string]bool{"*": true}
+
+	for _, branchType := range project.BranchTypes {
+		branchTypeNames[branchType.Name] = true
+	}
+
+	return branchTypeNames
+}
+
+// getRegexEngine returns the regex engine of the given project, unknown engines are reported at the
+// regexEngine key and fall back to the default engine.
+func getRegexEngine(project Project) string {
+	for _, engine := range regexadapter.Engines() {
+		if engine == project.RegexEngine {
+			return engine
+		}
+	}
+
+	return ""
+}
+
+func (l *linter) lintExtends(projectName string, extends []string) {
+	for i, presetName := range extends {
+		presetNode := l.nodeAt(projectName, "extends", i)
+		if isPresetFile(presetName) {
+			if !filepath.IsAbs(presetName) {
+				presetName = filepath.Join(filepath.Dir(l.filePath), presetName)
+			}
+			if _, err := os.Stat(presetName); err != nil {
+				l.addError(presetNode, fmt.Sprintf("preset file '%s' does not exist", presetName))
+			}
+			continue
+		}
+		if _, ok := l.configuration[presetName]; ok {
+			continue
+		}
+		if _, ok := BuiltInPresets[presetName]; !ok {
+			l.addError(presetNode, fmt.Sprintf("unknown preset '%s'", presetName))
+		}
+	}
+}
+
+func (l *linter) lintBranchTypes(projectName string, branchTypes BranchTypes) {
+	names := map[string]bool{}
+	for i, branchType := range branchTypes {
+		itemNode, nameNode, patternNode := l.branchTypeNodes(projectName, i)
+
+		if branchType.Name == "" {
+			l.addError(itemNode, "branch type must have a name")
+		} else if names[branchType.Name] {
+			l.addError(nameNode, fmt.Sprintf("duplicate branch type '%s'", branchType.Name))
+		} else {
+			names[branchType.Name] = true
+		}
+
+		if branchType.Pattern == "" {
+			l.addError(itemNode, "branch type must have a pattern")
+		} else {
+			l.lintPattern(patternNode, string(branchType.Pattern))
+		}
+	}
+}
+
+// branchTypeNodes returns the nodes of the branch type of the given index, its item in the list or its key in the
+// legacy map form, and the nodes of its name and pattern.
+func (l *linter) branchTypeNodes(projectName string, i int) (*yaml.Node, *yaml.Node, *yaml.Node) {
+	branchNode := l.nodeAt(projectName, "branch")
+	if branchNode.Kind == yaml.MappingNode {
+		if 2*i+1 < len(branchNode.Content) {
+			return branchNode.Content[2*i], branchNode.Content[2*i], branchNode.Content[2*i+1]
+		}
+		return branchNode, branchNode, branchNode
+	}
+
+	itemPath := []interface{}{projectName, "branch", i}
+
+	return l.nodeAt(itemPath...), l.nodeAt(appendPath(itemPath, "name")...), l.nodeAt(appendPath(itemPath, "pattern")...)
+}
+
+// forEachBranchType checks that every branch type of the given section is a known branch type and lints its value
+// by the given function, which gets the path of the value.
+func (l *linter) forEachBranchType(projectName, section string, values interface{}, branchTypeNames map[string]bool, lintValue func(path []interface{}, branchType string)) {
+	for _, branchType := range sortedKeys(values) {
+		path := []interface{}{projectName, section, branchType}
+		if branchTypeNames != nil && !branchTypeNames[branchType] {
+			l.addError(l.keyNodeAt(path...), fmt.Sprintf("unknown branch type '%s'", branchType))
+		}
+		lintValue(path, branchType)
+	}
+}
+
+func (l *linter) lintTemplate(node *yaml.Node, text string) {
+	if _, err := template.New("").Option("missingkey=zero").Funcs(templatefuncs.FuncMap(l.regexEngine)).Parse(text); err != nil {
+		l.addError(node, fmt.Sprintf("invalid template: %v", err))
+	}
+}
+
+func (l *linter) lintValidationRule(path []interface{}, rule ValidationRule) {
+	if groupName, err := rule.validate(); err != nil {
+		node := l.nodeAt(path...)
+		if groupName != "" {
+			node = l.nodeAt(appendPath(path, groupName)...)
+		}
+		l.addError(node, err.Error())
+	}
+
+	if rule.Pattern != "" {
+		l.lintPattern(l.nodeAt(appendPath(path, "pattern")...), rule.Pattern)
+	}
+
+	for _, group := range rule.groups() {
+		for i, nestedRule := range group.rules {
+			l.lintValidationRule(appendPath(path, group.name, i), nestedRule)
+		}
+	}
+}
+
+func (l *linter) lintTrailers(path []interface{}, trailerConfiguration TrailerConfiguration) {
+	for i, trailer := range trailerConfiguration.Add {
+		trailerPath := appendPath(path, "add", i)
+		if trailer.Key == "" {
+			l.addError(l.nodeAt(trailerPath...), "trailer must have a key")
+		} else {
+			l.lintTrailerKey(l.nodeAt(appendPath(trailerPath, "key")...), trailer.Key)
+		}
+		if trailer.Value == "" {
+			l.addError(l.nodeAt(trailerPath...), "trailer must have a value")
+		} else {
+			l.lintTemplate(l.nodeAt(appendPath(trailerPath, "value")...), string(trailer.Value))
+		}
+	}
+
+	for i, key := range trailerConfiguration.Require {
+		l.lintTrailerKey(l.nodeAt(appendPath(path, "require", i)...), key)
+	}
+}
+
+func (l *linter) lintTrailerKey(node *yaml.Node, key string) {
+	if !lintTrailerKey.MatchString(key) {
+		l.addError(node, fmt.Sprintf("invalid trailer key '%s', it may only contain letters, digits and hyphens", key))
+	}
+}
+
+func (l *linter) lintNoBranch(projectName string, noBranch NoBranchConfiguration, branchTypeNames map[string]bool) {
+	if noBranch.Action != "" {
+		l.expectOneOf(l.nodeAt(projectName, "noBranch", "action"), "action", string(noBranch.Action), lintNoBranchAction)
+	}
+	if noBranch.BranchType != "" && branchTypeNames != nil && !branchTypeNames[noBranch.BranchType] {
+		l.addError(l.nodeAt(projectName, "noBranch", "branchType"), fmt.Sprintf("unknown branch type '%s'", noBranch.BranchType))
+	}
+}
+
+func (l *linter) lintPattern(node *yaml.Node, pattern string) {
+	if _, err := regexadapter.Compile(l.regexEngine, pattern); err != nil {
+		l.addError(node, err.Error())
+	}
+}
+
+func (l *linter) expectOneOf(node *yaml.Node, name string, value string, allowed []string) {
+	for _, allowedValue := range allowed {
+		if value == allowedValue {
+			return
+		}
+	}
+
+	l.addError(node, fmt.Sprintf("unknown %s '%s', supported are: %s", name, value, strings.Join(allowed, ", ")))
+}
+
+func (l *linter) addError(node *yaml.Node, message string) {
+	lintError := LintError{FilePath: l.filePath, Message: message}
+	if l.format == ConfigurationFormatYAML {
+		lintError.Line, lintError.Column = node.Line, node.Column
+	}
+	l.errors = append(l.errors, lintError)
+}
+
+// nodeAt returns the value node at the given path of mapping keys and sequence indexes below the root of the file.
+// If the path does not exist, the deepest node found is returned, so problems are still reported close to their cause.
+func (l *linter) nodeAt(path ...interface{}) *yaml.Node {
+	node, _ := findPath(l.root, path)
+
+	return node
+}
+
+// keyNodeAt returns the key node of the mapping entry at the given path, or the deepest node found like nodeAt.
+func (l *linter) keyNodeAt(path ...interface{}) *yaml.Node {
+	_, keyNode := findPath(l.root, path)
+
+	return keyNode
+}
+
+func findPath(root *yaml.Node, path []interface{}) (*yaml.Node, *yaml.Node) {
+	node, keyNode := root, root
+	for _, element := range path {
+		if node.Kind == yaml.AliasNode && node.Alias != nil {
+			node = node.Alias
+		}
+		childNode, childKeyNode := findChildNode(node, element)
+		if childNode == nil {
+			break
+		}
+		node, keyNode = childNode, childKeyNode
+	}
+
+	return node, keyNode
+}
+
+// findChildNode returns the value and key node of the given mapping key or sequence index.
+func findChildNode(node *yaml.Node, element interface{}) (*yaml.Node, *yaml.Node) {
+	switch element := element.(type) {
+	case string:
+		if node.Kind != yaml.MappingNode {
+			break
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == element {
+				return node.Content[i+1], node.Content[i]
+			}
+		}
+	case int:
+		if node.Kind == yaml.SequenceNode && element < len(node.Content) {
+			return node.Content[element], node.Content[element]
+		}
+	}
+
+	return nil, nil
+}
+
+// findNodeOnLine returns the first node on the given line, preferably a scalar of the given value. Values shortened
+// by the yaml decoder, like 'abcdefg...', match by their beginning.
+func findNodeOnLine(root *yaml.Node, line int, value string) *yaml.Node {
+	var firstNode, valueNode *yaml.Node
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		if node.Line == line {
+			if firstNode == nil {
+				firstNode = node
+			}
+			if valueNode == nil && value != "" && node.Kind == yaml.ScalarNode && strings.HasPrefix(node.Value, strings.TrimSuffix(value, "...")) {
+				valueNode = node
+			}
+		}
+		for _, childNode := range node.Content {
+			walk(childNode)
+		}
+	}
+	walk(root)
+
+	switch {
+	case valueNode != nil:
+		return valueNode
+	case firstNode != nil:
+		return firstNode
+	default:
+		return &yaml.Node{Line: line}
+	}
+}
+
+func appendPath(path []interface{}, elements ...interface{}) []interface{} {
+	return append(append([]interface{}{}, path...), elements...)
+}
+
+// sortedKeys returns the keys of the given map of string keys in sorted order.
+func sortedKeys(values interface{}) []string {
+	mapValue := reflect.ValueOf(values)
+	keys := make([]string, 0, mapValue.Len())
+	for _, key := range mapValue.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// unknownKeyMessage reports a key that is not part of the config struct of the given type name, like
+// 'config.BranchType', and suggests a similar key.
+func unknownKeyMessage(key string, typeName string) string {
+	knownKeys := getYamlKeys(findConfigType(reflect.TypeOf(Configuration{}), typeName, map[reflect.Type]bool{}))
+	message := fmt.Sprintf("unknown key '%s'", key)
+	if suggestion := findSimilarKey(key, knownKeys); suggestion != "" {
+		message += fmt.Sprintf(", did you mean '%s'?", suggestion)
+	} else if len(knownKeys) > 0 {
+		message += fmt.Sprintf(", supported are: %s", strings.Join(knownKeys, ", "))
+	}
+
+	return message
+}
+
+// findConfigType returns the type of the given name that is part of the given type, like 'config.BranchType'.
+func findConfigType(configType reflect.Type, typeName string, visited map[reflect.Type]bool) reflect.Type {
+	if configType.String() == typeName {
+		return configType
+	}
+	if visited[configType] {
+		return nil
+	}
+	visited[configType] = true
+
+	switch configType.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Ptr:
+		return findConfigType(configType.Elem(), typeName, visited)
+	case reflect.Struct:
+		for i := 0; i < configType.NumField(); i++ {
+			if found := findConfigType(configType.Field(i).Type, typeName, visited); found != nil {
+				return found
+			}
+		}
+	}
+
+	return nil
+}
+
+// getYamlKeys returns the keys of the yaml tags of the given struct type.
+func getYamlKeys(structType reflect.Type) []string {
+	if structType == nil || structType.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var keys []string
+	for i := 0; i < structType.NumField(); i++ {
+		key := strings.Split(structType.Field(i).Tag.Get("yaml"), ",")[0]
+		if key != "" && key != "-" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// findSimilarKey returns the known key that differs by at most two edits from the given key.
+func findSimilarKey(key string, knownKeys []string) string {
+	for _, knownKey := range knownKeys {
+		if levenshtein(strings.ToLower(key), strings.ToLower(knownKey)) <= 2 {
+			return knownKey
+		}
+	}
+
+	return ""
+}
+
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+
+	return previous[len(b)]
+}
+
+func minInt(values ...int) int {
+	minimum := values[0]
+	for _, value := range values[1:] {
+		if value < minimum {
+			minimum = value
+		}
+	}
+
+	return minimum
+}
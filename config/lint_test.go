@@ -0,0 +1,300 @@
+package config
+
+import (
+	"path"
+	"testing"
+
+	"github.com/Oppodelldog/git-commit-hook/testhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintConfigurationLayers(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.CleanupTestEnvironment(t)
+	writeTestFiles(t, map[string]string{
+		"user.yaml": `base:
+  branch:
+    - name: release
+      pattern: "^release/.*$"
+`,
+		"repository.yaml": `acme:
+  path: /home/dev/acme/.git
+  extends: [base, gitflow]
+  branch:
+    - name: feature
+      pattern: "^feature/(.*$"
+      priority: high
+    - name: feature
+      pattern: "^feat/.*$"
+      color: red
+  template:
+    feature: "{{.BranchName}: {{.CommitMessage}}"
+    release: "{{.CommitMessage}}"
+    bugfix: "{{.CommitMessage}}"
+  validations:
+    "*":
+      "[A-Z]+-[0-9]+": ticket
+  validation:
+    "*":
+      "([A-Z]+-[0-9]+": ticket
+  rules:
+    "*":
+      allOf:
+        - pattern: "WIP("
+        - subjectMaxLength: 72
+          describtion: typo
+  conventional:
+    "*":
+      requireScope: maybe
+  kinds:
+    merge: validate
+    cherry-pick: skip
+    fixup: ignore
+  noBranch:
+    action: fallback
+    branchType: detached
+`,
+	})
+	userLayer := ConfigurationLayer{Scope: ConfigurationScopeUser, FilePath: path.Join(testhelper.TestPath, "user.yaml")}
+	repositoryLayer := ConfigurationLayer{Scope: ConfigurationScopeRepository, FilePath: path.Join(testhelper.TestPath, "repository.yaml")}
+
+	lintErrors := LintConfigurationLayers([]ConfigurationLayer{userLayer, repositoryLayer})
+
+	filePath := repositoryLayer.FilePath
+	assert.Exactly(t, []LintError{
+		{FilePath: filePath, Line: 6, Column: 16, Message: "invalid pattern '^feature/(.*$': error parsing regexp: missing closing ): `^feature/(.*$`"},
+		{FilePath: filePath, Line: 7, Column: 17, Message: "cannot unmarshal !!str `high` into int"},
+		{FilePath: filePath, Line: 8, Column: 13, Message: "duplicate branch type 'feature'"},
+		{FilePath: filePath, Line: 10, Column: 7, Message: "unknown key 'color', supported are: name, pattern, priority"},
+		{FilePath: filePath, Line: 12, Column: 14, Message: "invalid template: template: :1: bad character U+007D '}'"},
+		{FilePath: filePath, Line: 14, Column: 5, Message: "unknown branch type 'bugfix'"},
+		{FilePath: filePath, Line: 15, Column: 3, Message: "unknown key 'validations', did you mean 'validation'?"},
+		{FilePath: filePath, Line: 20, Column: 7, Message: "invalid pattern '([A-Z]+-[0-9]+': error parsing regexp: missing closing ): `([A-Z]+-[0-9]+`"},
+		{FilePath: filePath, Line: 24, Column: 20, Message: "invalid pattern 'WIP(': error parsing regexp: missing closing ): `WIP(`"},
+		{FilePath: filePath, Line: 26, Column: 11, Message: "unknown key 'describtion', did you mean 'description'?"},
+		{FilePath: filePath, Line: 29, Column: 21, Message: "cannot unmarshal !!str `maybe` into bool"},
+		{FilePath: filePath, Line: 32, Column: 5, Message: "unknown message kind 'cherry-pick', supported are: merge, squash, fixup, revert"},
+		{FilePath: filePath, Line: 33, Column: 12, Message: "unknown action 'ignore', supported are: default, skip, template, validate"},
+		{FilePath: filePath, Line: 36, Column: 17, Message: "unknown branch type 'detached'"},
+	}, lintErrors)
+}
+
+func TestLintConfigurationLayers_RulesWithoutCheck(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.CleanupTestEnvironment(t)
+	writeTestFiles(t, map[string]string{
+		"git-commit-hook.yaml": `acme:
+  rules:
+    "*":
+      anyOf: []
+      noneOf:
+        - description: not contain WIP
+`,
+	})
+	filePath := path.Join(testhelper.TestPath, "git-commit-hook.yaml")
+
+	lintErrors := LintConfigurationLayers([]ConfigurationLayer{{Scope: ConfigurationScopeUser, FilePath: filePath}})
+
+	assert.Exactly(t, []LintError{
+		{FilePath: filePath, Line: 4, Column: 14, Message: "anyOf must hold at least one rule"},
+		{FilePath: filePath, Line: 6, Column: 11, Message: "rule must have a pattern, a subjectMaxLength or a group of rules"},
+	}, lintErrors)
+}
+
+func TestLintConfigurationLayers_AcceptsWhatIsLoaded(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.CleanupTestEnvironment(t)
+	writeTestFiles(t, map[string]string{
+		"git-commit-hook.yaml": `acme:
+  conventional:
+    "*":
+      requireScope: yes
+`,
+	})
+	filePath := path.Join(testhelper.TestPath, "git-commit-hook.yaml")
+
+	lintErrors := LintConfigurationLayers([]ConfigurationLayer{{Scope: ConfigurationScopeUser, FilePath: filePath}})
+	configuration, err := parse(filePath)
+
+	assert.Empty(t, lintErrors)
+	assert.NoError(t, err)
+	assert.True(t, (*configuration)["acme"].Conventional["*"].RequireScope)
+}
+
+func TestLintConfigurationLayers_UnknownPresets(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.CleanupTestEnvironment(t)
+	writeTestFiles(t, map[string]string{
+		"git-commit-hook.yaml": `acme:
+  extends: [gitflow, does-not-exist, missing.yaml]
+  template:
+    bugfix: "{{.CommitMessage}}"
+`,
+	})
+	filePath := path.Join(testhelper.TestPath, "git-commit-hook.yaml")
+
+	lintErrors := LintConfigurationLayers([]ConfigurationLayer{{Scope: ConfigurationScopeUser, FilePath: filePath}})
+
+	assert.Exactly(t, []LintError{
+		{FilePath: filePath, Line: 2, Column: 22, Message: "unknown preset 'does-not-exist'"},
+		{FilePath: filePath, Line: 2, Column: 38, Message: "preset file '" + path.Join(testhelper.TestPath, "missing.yaml") + "' does not exist"},
+	}, lintErrors)
+}
+
+func TestLintConfigurationLayers_ValidConfiguration(t *testing.T) {
+	lintErrors := LintConfigurationLayers([]ConfigurationLayer{{Scope: ConfigurationScopeUser, FilePath: "test-data.yaml"}})
+
+	assert.Empty(t, lintErrors)
+}
+
+func TestLintConfigurationLayers_SyntaxError(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.CleanupTestEnvironment(t)
+	writeTestFiles(t, map[string]string{"git-commit-hook.yaml": "acme:\n  path: x\n  template: [\n"})
+	filePath := path.Join(testhelper.TestPath, "git-commit-hook.yaml")
+
+	lintErrors := LintConfigurationLayers([]ConfigurationLayer{{Scope: ConfigurationScopeUser, FilePath: filePath}})
+
+	assert.Len(t, lintErrors, 1)
+	assert.Exactly(t, filePath, lintErrors[0].FilePath)
+	assert.Exactly(t, 3, lintErrors[0].Line)
+}
+
+func TestLintConfigurationLayers_CyclicExtends(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.CleanupTestEnvironment(t)
+	writeTestFiles(t, map[string]string{"git-commit-hook.yaml": "a:\n  extends: [b]\nb:\n  extends: [a]\n"})
+	filePath := path.Join(testhelper.TestPath, "git-commit-hook.yaml")
+
+	lintErrors := LintConfigurationLayers([]ConfigurationLayer{{Scope: ConfigurationScopeUser, FilePath: filePath}})
+
+	assert.Exactly(t, []LintError{
+		{FilePath: filePath, Line: 2, Column: 12, Message: "cyclic extends: a -> b -> a"},
+		{FilePath: filePath, Line: 4, Column: 12, Message: "cyclic extends: b -> a -> b"},
+	}, lintErrors)
+}
+
+func TestLintConfigurationLayers_RegexEngine(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.CleanupTestEnvironment(t)
+	writeTestFiles(t, map[string]string{"git-commit-hook.yaml": `
+pcre project:
+  regexEngine: pcre
+  branch:
+    - name: feature
+      pattern: "^feature/(?!WIP).*$"
+re2 project:
+  branch:
+    - name: feature
+      pattern: "^feature/(?!WIP).*$"
+    - name: release
+      pattern: "pcre:^release/(?!WIP).*$"
+unknown engine project:
+  regexEngine: perl
+`})
+	filePath := path.Join(testhelper.TestPath, "git-commit-hook.yaml")
+
+	lintErrors := LintConfigurationLayers([]ConfigurationLayer{{Scope: ConfigurationScopeUser, FilePath: filePath}})
+
+	assert.Exactly(t, []LintError{
+		{FilePath: filePath, Line: 10, Column: 16, Message: "invalid pattern '^feature/(?!WIP).*$': error parsing regexp: invalid or unsupported Perl syntax: `(?!`"},
+		{FilePath: filePath, Line: 14, Column: 16, Message: "unknown regex engine 'perl', supported are: pcre, re2"},
+	}, lintErrors)
+}
+
+func TestLintConfigurationLayers_TemplateFunctions(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.CleanupTestEnvironment(t)
+	writeTestFiles(t, map[string]string{
+		"git-commit-hook.yaml": `acme:
+  branch:
+    feature: "^feature/.*$"
+  template:
+    feature: "{{.BranchName | ticketFromBranch | upper}}: {{.CommitMessage | firstLine | wrap 72}}"
+  prepare:
+    feature: "{{.BranchName | kebab}}: "
+`,
+	})
+	filePath := path.Join(testhelper.TestPath, "git-commit-hook.yaml")
+
+	lintErrors := LintConfigurationLayers([]ConfigurationLayer{{Scope: ConfigurationScopeUser, FilePath: filePath}})
+
+	assert.Exactly(t, []LintError{
+		{FilePath: filePath, Line: 7, Column: 14, Message: `invalid template: template: :1: function "kebab" not defined`},
+	}, lintErrors)
+}
+
+func TestLintConfigurationLayers_JSONAndTOML(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.CleanupTestEnvironment(t)
+	writeTestFiles(t, map[string]string{
+		"user.json": `{"acme": {"branch": [{"name": "feature", "pattern": "^feature/.*$"}], "tempalte": {}}}`,
+		"repository.toml": `
+[acme.template]
+featrue = "{{.CommitMessage}}"
+`,
+		"syntax.json": `{"acme": `,
+	})
+	userFilePath := path.Join(testhelper.TestPath, "user.json")
+	repositoryFilePath := path.Join(testhelper.TestPath, "repository.toml")
+	syntaxFilePath := path.Join(testhelper.TestPath, "syntax.json")
+
+	lintErrors := LintConfigurationLayers([]ConfigurationLayer{
+		{Scope: ConfigurationScopeUser, FilePath: userFilePath},
+		{Scope: ConfigurationScopeRepository, FilePath: repositoryFilePath},
+		{Scope: ConfigurationScopeLocal, FilePath: syntaxFilePath},
+	})
+
+	assert.Exactly(t, []LintError{
+		{FilePath: userFilePath, Message: "unknown key 'tempalte', did you mean 'template'?"},
+		{FilePath: repositoryFilePath, Message: "unknown branch type 'featrue'"},
+		{FilePath: syntaxFilePath, Message: "json: unexpected EOF"},
+	}, lintErrors)
+}
+
+func TestLintError_Error(t *testing.T) {
+	lintError := LintError{FilePath: "/a.yaml", Line: 3, Column: 5, Message: "unknown key 'x'"}
+
+	assert.Exactly(t, "/a.yaml:3:5: unknown key 'x'", lintError.Error())
+}
+
+func TestLintError_Error_WithoutPosition(t *testing.T) {
+	lintError := LintError{FilePath: "/a.json", Message: "unknown key 'x'"}
+
+	assert.Exactly(t, "/a.json: unknown key 'x'", lintError.Error())
+}
+
+func TestLintConfigurationLayers_Trailers(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.CleanupTestEnvironment(t)
+	writeTestFiles(t, map[string]string{"git-commit-hook.yaml": `acme:
+  branch:
+    - name: feature
+      pattern: "^feature/(?P<ticket>[A-Z]+-[0-9]+)"
+  trailers:
+    feature:
+      add:
+        - key: Ticket
+          value: "{{.Branch.ticket}"
+        - key: Signed off by
+          value: "{{.AuthorName}} <{{.AuthorEmail}}>"
+        - value: "{{.AuthorName}}"
+          color: red
+      require: [Ticket, "Reviewed by"]
+    bugfix:
+      require: Ticket
+`})
+	filePath := path.Join(testhelper.TestPath, "git-commit-hook.yaml")
+
+	lintErrors := LintConfigurationLayers([]ConfigurationLayer{{Scope: ConfigurationScopeUser, FilePath: filePath}})
+
+	assert.Exactly(t, []LintError{
+		{FilePath: filePath, Line: 9, Column: 18, Message: "invalid template: template: :1: bad character U+007D '}'"},
+		{FilePath: filePath, Line: 10, Column: 16, Message: "invalid trailer key 'Signed off by', it may only contain letters, digits and hyphens"},
+		{FilePath: filePath, Line: 12, Column: 11, Message: "trailer must have a key"},
+		{FilePath: filePath, Line: 13, Column: 11, Message: "unknown key 'color', supported are: key, value"},
+		{FilePath: filePath, Line: 14, Column: 25, Message: "invalid trailer key 'Reviewed by', it may only contain letters, digits and hyphens"},
+		{FilePath: filePath, Line: 15, Column: 5, Message: "unknown branch type 'bugfix'"},
+		{FilePath: filePath, Line: 16, Column: 16, Message: "cannot unmarshal !!str `Ticket` into []string"},
+	}, lintErrors)
+}
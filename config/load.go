@@ -3,32 +3,31 @@ package config
 import (
 	"path/filepath"
 
-	"github.com/Oppodelldog/filediscovery"
+	"github.com/Oppodelldog/git-commit-hook/git"
 )
 
-const configFilename = "git-commit-hook.yaml"
-
 //LoadConfiguration loads the git-commit-hook configuration from file.
+// All configuration layers found are merged, see FindConfigurationLayers.
 func LoadConfiguration() (*Configuration, error) {
-	filePath, err := FindConfigurationFilePath()
+	layers, err := FindConfigurationLayers()
 	if err != nil {
 		return nil, err
 	}
 
-	return parse(filePath)
+	configuration, _, err := LoadConfigurationLayers(layers)
+
+	return configuration, err
 }
 
 //FindConfigurationFilePath searches the git-commit-hook config file in various places and returns
-// the first found filepath or error
+// the filepath of the layer with the highest precedence or error
 func FindConfigurationFilePath() (string, error) {
+	layers, err := FindConfigurationLayers()
+	if err != nil {
+		return "", err
+	}
 
-	return filediscovery.New([]filediscovery.FileLocationProvider{
-		filediscovery.WorkingDirProvider(".git", "hooks"),
-		filediscovery.WorkingDirProvider(".git"),
-		filediscovery.WorkingDirProvider(),
-		filediscovery.ExecutableDirProvider(),
-		filediscovery.HomeConfigDirProvider(".config", "git-commit-hook"),
-	}).Discover(configFilename)
+	return layers[len(layers)-1].FilePath, nil
 }
 
 // LoadProjectConfigurationByName loads a project configuration by its name
@@ -42,7 +41,10 @@ func LoadProjectConfigurationByName(projectName string) (Project, error) {
 	return configuration.GetProjectByName(projectName)
 }
 
-// LoadProjectConfigurationFromCommitMessageFileDir loads a project configuration by resolving a given commit-message file
+// LoadProjectConfigurationFromCommitMessageFileDir loads a project configuration by resolving a given commit-message file.
+// Besides the directory of the file, projects are matched against the common git dir, the worktree root and the
+// superprojects, so linked worktrees and submodules resolve to the project they belong to.
+// If no project matches by path, projects are matched by the remote urls of the repository.
 func LoadProjectConfigurationFromCommitMessageFileDir(commitMessageFile string) (Project, error) {
 
 	projectPath, err := filepath.Abs(filepath.Dir(commitMessageFile))
@@ -55,5 +57,68 @@ func LoadProjectConfigurationFromCommitMessageFileDir(commitMessageFile string)
 		return Project{}, err
 	}
 
-	return configuration.GetProjectByRepoPath(projectPath)
+	return findRepositoryProject(*configuration, projectPath)
+}
+
+// findRepositoryProject returns the project of the repository at the given path, it is matched by path first,
+// then by the remote urls of the repository.
+func findRepositoryProject(configuration Configuration, projectPath string) (Project, error) {
+	projectCfg, err := configuration.GetProjectByRepoPaths(getRepositoryPathCandidates(projectPath)...)
+	if err == nil {
+		return projectCfg, nil
+	}
+
+	remoteURLs := getRemoteURLs(projectPath)
+	if len(remoteURLs) == 0 {
+		return Project{}, err
+	}
+
+	return configuration.GetProjectByRemoteURLs(remoteURLs...)
+}
+
+func getRemoteURLs(projectPath string) []string {
+	gitDir, err := git.FindGitDir(projectPath)
+	if err != nil {
+		return nil
+	}
+
+	return git.GetRemoteURLs(gitDir)
+}
+
+// getRepositoryPathCandidates returns the paths a project may be configured with for the given git dir,
+// the most specific path comes first.
+func getRepositoryPathCandidates(projectPath string) []string {
+	candidates := []string{projectPath}
+
+	gitDir, err := git.FindGitDir(projectPath)
+	if err != nil {
+		return candidates
+	}
+
+	repositoryPaths := git.GetRepositoryPaths(gitDir)
+	candidates = append(candidates, repositoryPaths.GitDir, repositoryPaths.CommonDir)
+	if repositoryPaths.WorktreeRoot != "" {
+		candidates = append(candidates, filepath.Join(repositoryPaths.WorktreeRoot, ".git"), repositoryPaths.WorktreeRoot)
+	}
+	for _, superprojectGitDir := range repositoryPaths.SuperprojectGitDirs {
+		candidates = append(candidates, superprojectGitDir)
+		if filepath.Base(superprojectGitDir) == ".git" {
+			candidates = append(candidates, filepath.Dir(superprojectGitDir))
+		}
+	}
+
+	return unique(candidates)
+}
+
+func unique(values []string) []string {
+	var uniqueValues []string
+	seen := map[string]bool{}
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			uniqueValues = append(uniqueValues, value)
+		}
+	}
+
+	return uniqueValues
 }
@@ -1,8 +1,12 @@
 package config
 
 import (
+	"io/ioutil"
+	"os"
+	"path"
 	"testing"
 
+	"github.com/Oppodelldog/git-commit-hook/testhelper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -10,3 +14,82 @@ func TestLoadConfiguration_NotFoundError(t *testing.T) {
 	_, err := LoadConfiguration()
 	assert.Error(t, err)
 }
+
+func TestGetRepositoryPathCandidates_ProjectIsFoundForWorktreesAndSubmodules(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.CleanupTestEnvironment(t)
+	writeTestFiles(t, map[string]string{
+		"main/.git/HEAD":                       "ref: refs/heads/master\n",
+		"main/.git/objects/.keep":              "",
+		"main/.git/worktrees/linked/HEAD":      "ref: refs/heads/feature/x\n",
+		"main/.git/worktrees/linked/commondir": "../..\n",
+		"main/.git/worktrees/linked/gitdir":    path.Join(testhelper.TestPath, "linked", ".git") + "\n",
+		"main/.git/modules/sub/HEAD":           "ref: refs/heads/master\n",
+		"main/.git/modules/sub/objects/.keep":  "",
+		"main/.git/modules/sub/refs/.keep":     "",
+		"main/.git/modules/sub/config":         "[core]\n\tworktree = ../../../sub\n",
+		"linked/.git":                          "gitdir: ../main/.git/worktrees/linked\n",
+	})
+
+	testCases := map[string]struct {
+		projectPath   string
+		commitMessage string
+	}{
+		"main worktree, configured by git dir":      {projectPath: "main/.git", commitMessage: "main/.git/COMMIT_EDITMSG"},
+		"main worktree, configured by root":         {projectPath: "main", commitMessage: "main/.git/COMMIT_EDITMSG"},
+		"linked worktree, configured by main":       {projectPath: "main/.git", commitMessage: "main/.git/worktrees/linked/COMMIT_EDITMSG"},
+		"linked worktree, configured by its root":   {projectPath: "linked", commitMessage: "main/.git/worktrees/linked/COMMIT_EDITMSG"},
+		"submodule, configured by superproject":     {projectPath: "main/.git", commitMessage: "main/.git/modules/sub/COMMIT_EDITMSG"},
+		"submodule, configured by superproject dir": {projectPath: "main", commitMessage: "main/.git/modules/sub/COMMIT_EDITMSG"},
+		"submodule, configured by its root":         {projectPath: "main/sub", commitMessage: "main/.git/modules/sub/COMMIT_EDITMSG"},
+	}
+
+	for testName, testData := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			cfg := Configuration{
+				"other":   Project{Path: path.Join(testhelper.TestPath, "other", ".git")},
+				"project": Project{Path: path.Join(testhelper.TestPath, testData.projectPath)},
+			}
+
+			candidates := getRepositoryPathCandidates(path.Dir(path.Join(testhelper.TestPath, testData.commitMessage)))
+			projectCfg, err := cfg.GetProjectByRepoPaths(candidates...)
+
+			assert.NoError(t, err)
+			assert.Exactly(t, cfg.getProject("project"), projectCfg)
+		})
+	}
+}
+
+func TestGetRepositoryPathCandidates_SubmoduleOwnConfigurationWins(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.CleanupTestEnvironment(t)
+	writeTestFiles(t, map[string]string{
+		"main/.git/HEAD":                      "ref: refs/heads/master\n",
+		"main/.git/objects/.keep":             "",
+		"main/.git/modules/sub/HEAD":          "ref: refs/heads/master\n",
+		"main/.git/modules/sub/objects/.keep": "",
+	})
+	cfg := Configuration{
+		"main": Project{Path: path.Join(testhelper.TestPath, "main", ".git")},
+		"sub":  Project{Path: path.Join(testhelper.TestPath, "main", ".git", "modules", "sub")},
+	}
+
+	candidates := getRepositoryPathCandidates(path.Join(testhelper.TestPath, "main", ".git", "modules", "sub"))
+	projectCfg, err := cfg.GetProjectByRepoPaths(candidates...)
+
+	assert.NoError(t, err)
+	assert.Exactly(t, cfg.getProject("sub"), projectCfg)
+}
+
+func writeTestFiles(t *testing.T, files map[string]string) {
+	t.Helper()
+	for fileName, content := range files {
+		filePath := path.Join(testhelper.TestPath, fileName)
+		if err := os.MkdirAll(path.Dir(filePath), 0777); err != nil {
+			t.Fatalf("Did not expect os.MkdirAll to return an error, but got: %v ", err)
+		}
+		if err := ioutil.WriteFile(filePath, []byte(content), 0666); err != nil {
+			t.Fatalf("Did not expect ioutil.WriteFile to return an error, but got: %v ", err)
+		}
+	}
+}
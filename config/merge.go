@@ -0,0 +1,106 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// mergeConfiguration merges the projects of overlay into configuration and records the origin of every value that
+// was taken from overlay. Projects of the same name are merged by mergeProject.
+func mergeConfiguration(configuration Configuration, overlay Configuration, layer ConfigurationLayer, origins ConfigurationOrigins) {
+	for projectName, overlayProject := range overlay {
+		configuration[projectName] = mergeProject(configuration[projectName], overlayProject, projectName, layer, origins)
+	}
+}
+
+// mergeProject returns a copy of project that is overridden by overlay:
+//   - path, remote, extends, regexEngine and the noBranch settings are overridden if set
+//   - branch types are overridden by name, new branch types are appended
+//   - templates, prepare templates, validation, rules, conventional, trailers and kinds are overridden by branch type or kind
+//
+// The origin of every value taken from overlay is recorded, if origins are given.
+func mergeProject(project Project, overlay Project, projectName string, layer ConfigurationLayer, origins ConfigurationOrigins) Project {
+	if overlay.Path != "" {
+		project.Path = overlay.Path
+		origins.Set(projectName, "path", "", layer)
+	}
+	if overlay.Remote != "" {
+		project.Remote = overlay.Remote
+		origins.Set(projectName, "remote", "", layer)
+	}
+	if len(overlay.Extends) > 0 {
+		project.Extends = overlay.Extends
+		origins.Set(projectName, "extends", "", layer)
+	}
+	if overlay.RegexEngine != "" {
+		project.RegexEngine = overlay.RegexEngine
+		origins.Set(projectName, "regexEngine", "", layer)
+	}
+
+	project.BranchTypes = mergeBranchTypes(project.BranchTypes, overlay.BranchTypes)
+	for _, branchType := range overlay.BranchTypes {
+		origins.Set(projectName, "branch", branchType.Name, layer)
+	}
+
+	mergeBranchTypeMaps(&project, overlay, projectName, layer, origins)
+
+	if overlay.NoBranch.Action != "" {
+		project.NoBranch.Action = overlay.NoBranch.Action
+		origins.Set(projectName, "noBranch", "action", layer)
+	}
+	if overlay.NoBranch.BranchType != "" {
+		project.NoBranch.BranchType = overlay.NoBranch.BranchType
+		origins.Set(projectName, "noBranch", "branchType", layer)
+	}
+
+	return project
+}
+
+func mergeBranchTypes(branchTypes BranchTypes, overlay BranchTypes) BranchTypes {
+	if len(overlay) == 0 {
+		return branchTypes
+	}
+
+	merged := make(BranchTypes, len(branchTypes), len(branchTypes)+len(overlay))
+	copy(merged, branchTypes)
+
+overlayLoop:
+	for _, overlayBranchType := range overlay {
+		for i := range merged {
+			if merged[i].Name == overlayBranchType.Name {
+				merged[i] = overlayBranchType
+				continue overlayLoop
+			}
+		}
+		merged = append(merged, overlayBranchType)
+	}
+
+	return merged
+}
+
+// mergeBranchTypeMaps overrides every map of project, like the templates or rules per branch type, by the keys of
+// the same map of overlay. The origin of every key taken from overlay is recorded in the section of the map's yaml key.
+// New maps of Project are merged without further ado.
+func mergeBranchTypeMaps(project *Project, overlay Project, projectName string, layer ConfigurationLayer, origins ConfigurationOrigins) {
+	projectValue := reflect.ValueOf(project).Elem()
+	overlayValue := reflect.ValueOf(overlay)
+	for i := 0; i < projectValue.NumField(); i++ {
+		field := projectValue.Type().Field(i)
+		overlayMap := overlayValue.Field(i)
+		if field.Type.Kind() != reflect.Map || overlayMap.Len() == 0 {
+			continue
+		}
+
+		projectMap := projectValue.Field(i)
+		merged := reflect.MakeMapWithSize(field.Type, projectMap.Len()+overlayMap.Len())
+		for _, key := range projectMap.MapKeys() {
+			merged.SetMapIndex(key, projectMap.MapIndex(key))
+		}
+		section := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		for _, key := range overlayMap.MapKeys() {
+			merged.SetMapIndex(key, overlayMap.MapIndex(key))
+			origins.Set(projectName, section, key.String(), layer)
+		}
+		projectMap.Set(merged)
+	}
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeProject_BranchTypeMaps(t *testing.T) {
+	project := Project{
+		Templates:    map[string]BranchTypeTemplate{"*": "{{.CommitMessage}}", "feature": "feature"},
+		Rules:        map[string]ValidationRule{"release": {SubjectMaxLength: 50}},
+		MessageKinds: map[string]MessageKindAction{"merge": MessageKindActionSkip},
+	}
+	overlay := Project{
+		Templates:        map[string]BranchTypeTemplate{"feature": "{{.BranchName}}: {{.CommitMessage}}"},
+		PrepareTemplates: map[string]BranchTypeTemplate{"*": "{{.BranchName}}: "},
+		Validation:       map[string]BranchValidationConfiguration{"*": {"[A-Z]+-[0-9]+": "ticket"}},
+		Rules:            map[string]ValidationRule{"release": {SubjectMaxLength: 72}},
+		Conventional:     map[string]ConventionalCommitConfiguration{"*": {RequireScope: true}},
+		Trailers:         map[string]TrailerConfiguration{"*": {Require: []string{"Refs"}}},
+		MessageKinds:     map[string]MessageKindAction{"fixup": MessageKindActionValidate},
+	}
+	layer := ConfigurationLayer{Scope: ConfigurationScopeRepository, FilePath: "repository.yaml"}
+	origins := ConfigurationOrigins{}
+
+	merged := mergeProject(project, overlay, "acme", layer, origins)
+
+	assert.Exactly(t, Project{
+		Templates:        map[string]BranchTypeTemplate{"*": "{{.CommitMessage}}", "feature": "{{.BranchName}}: {{.CommitMessage}}"},
+		PrepareTemplates: map[string]BranchTypeTemplate{"*": "{{.BranchName}}: "},
+		Validation:       map[string]BranchValidationConfiguration{"*": {"[A-Z]+-[0-9]+": "ticket"}},
+		Rules:            map[string]ValidationRule{"release": {SubjectMaxLength: 72}},
+		Conventional:     map[string]ConventionalCommitConfiguration{"*": {RequireScope: true}},
+		Trailers:         map[string]TrailerConfiguration{"*": {Require: []string{"Refs"}}},
+		MessageKinds:     map[string]MessageKindAction{"merge": MessageKindActionSkip, "fixup": MessageKindActionValidate},
+	}, merged)
+	assert.Exactly(t, BranchTypeTemplate("feature"), project.Templates["feature"], "the maps of project are not changed")
+	assert.Exactly(t, ConfigurationOrigins{
+		originKey("acme", "template", "feature"): layer,
+		originKey("acme", "prepare", "*"):        layer,
+		originKey("acme", "validation", "*"):     layer,
+		originKey("acme", "rules", "release"):    layer,
+		originKey("acme", "conventional", "*"):   layer,
+		originKey("acme", "trailers", "*"):       layer,
+		originKey("acme", "kinds", "fixup"):      layer,
+	}, origins)
+}
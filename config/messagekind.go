@@ -0,0 +1,30 @@
+package config
+
+// MessageKindAction defines how commit messages of a special kind, like merge or fixup commits, are handled
+type MessageKindAction string
+
+const (
+	// MessageKindActionDefault renders the template and validates the commit message
+	MessageKindActionDefault MessageKindAction = "default"
+	// MessageKindActionSkip leaves the commit message untouched
+	MessageKindActionSkip MessageKindAction = "skip"
+	// MessageKindActionTemplate renders the template, but does not validate the commit message
+	MessageKindActionTemplate MessageKindAction = "template"
+	// MessageKindActionValidate validates the commit message, but does not render the template
+	MessageKindActionValidate MessageKindAction = "validate"
+)
+
+// GetMessageKindAction returns the action configured for the given message kind.
+// Regular commit messages (empty kind) default to MessageKindActionDefault, all other kinds
+// default to MessageKindActionSkip.
+func (projConf *Project) GetMessageKindAction(messageKind string) MessageKindAction {
+	if action, ok := projConf.MessageKinds[messageKind]; ok {
+		return action
+	}
+
+	if messageKind == "" {
+		return MessageKindActionDefault
+	}
+
+	return MessageKindActionSkip
+}
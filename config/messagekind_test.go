@@ -0,0 +1,19 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMessageKindAction(t *testing.T) {
+	cfg := &Project{
+		MessageKinds: map[string]MessageKindAction{
+			"revert": MessageKindActionValidate,
+		},
+	}
+
+	assert.Exactly(t, MessageKindActionDefault, cfg.GetMessageKindAction(""))
+	assert.Exactly(t, MessageKindActionValidate, cfg.GetMessageKindAction("revert"))
+	assert.Exactly(t, MessageKindActionSkip, cfg.GetMessageKindAction("merge"))
+}
@@ -0,0 +1,32 @@
+package config
+
+// NoBranchAction defines what to do if the current branch cannot be determined, e.g. on a detached HEAD
+type NoBranchAction string
+
+const (
+	// NoBranchActionSkip leaves the commit message untouched
+	NoBranchActionSkip NoBranchAction = "skip"
+	// NoBranchActionFallback uses the configured fallback branch type
+	NoBranchActionFallback NoBranchAction = "fallback"
+	// NoBranchActionFail rejects the commit
+	NoBranchActionFail NoBranchAction = "fail"
+)
+
+type (
+	// NoBranchConfiguration defines the behavior if the current branch cannot be determined
+	NoBranchConfiguration struct {
+		// Action defines what to do, defaults to NoBranchActionSkip
+		Action NoBranchAction `yaml:"action,omitempty"`
+		// BranchType is the branch type used for NoBranchActionFallback
+		BranchType string `yaml:"branchType,omitempty"`
+	}
+)
+
+// GetNoBranchAction returns the configured action if the current branch cannot be determined
+func (projConf *Project) GetNoBranchAction() NoBranchAction {
+	if projConf.NoBranch.Action == "" {
+		return NoBranchActionSkip
+	}
+
+	return projConf.NoBranch.Action
+}
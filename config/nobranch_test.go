@@ -0,0 +1,25 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNoBranchAction(t *testing.T) {
+	assert.Exactly(t, NoBranchActionSkip, (&Project{}).GetNoBranchAction())
+	assert.Exactly(t, NoBranchActionFail, (&Project{NoBranch: NoBranchConfiguration{Action: NoBranchActionFail}}).GetNoBranchAction())
+}
+
+func TestGetBranchType_NoBranchFallback(t *testing.T) {
+	cfg := &Project{
+		BranchTypes: BranchTypes{{Name: "any", Pattern: `.*`}},
+		NoBranch:    NoBranchConfiguration{Action: NoBranchActionFallback, BranchType: "detached"},
+	}
+
+	assertBranchType(t, cfg, "", "detached")
+	assertBranchType(t, cfg, "develop", "any")
+
+	cfg.NoBranch.Action = NoBranchActionSkip
+	assertBranchType(t, cfg, "", "any")
+}
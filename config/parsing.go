@@ -1,27 +1,60 @@
 package config
 
 import (
-	"io/ioutil"
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
 
-	"gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
 )
 
 func parse(filepath string) (*Configuration, error) {
-	fileContent, err := ioutil.ReadFile(filepath)
+	fileContent, err := readConfigurationFile(filepath)
 	if err != nil {
 		return nil, err
 	}
 
-	return parseFromBytes(fileContent)
+	configuration, err := parseFromBytes(fileContent)
+
+	return configuration, removeLineNumbers(err, GetConfigurationFormat(filepath))
 }
 
 func parseFromBytes(bytes []byte) (*Configuration, error) {
 	var conf Configuration
 
-	err := yaml.Unmarshal(bytes, &conf)
+	err := decodeStrict(bytes, &conf)
 	if err != nil {
 		return nil, err
 	}
 
+	projectNames := make([]string, 0, len(conf))
+	for projectName := range conf {
+		projectNames = append(projectNames, projectName)
+	}
+	sort.Strings(projectNames)
+
+	for _, projectName := range projectNames {
+		if err := validateRules(conf[projectName]); err != nil {
+			return nil, fmt.Errorf("project '%s': %v", projectName, err)
+		}
+	}
+
 	return &conf, nil
 }
+
+// decodeStrict decodes the given yaml document into out. Unknown keys and values of the wrong type are errors,
+// the rest of the document is decoded nevertheless. Configuration files, preset files and the linter share it,
+// so they agree on what is valid.
+func decodeStrict(fileContent []byte, out interface{}) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(fileContent))
+	decoder.KnownFields(true)
+
+	err := decoder.Decode(out)
+	if err == io.EOF {
+		// an empty document
+		return nil
+	}
+
+	return err
+}
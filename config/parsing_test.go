@@ -15,12 +15,12 @@ func TestParse(t *testing.T) {
 	expectedConfig := &Configuration{
 		"project xyz": {
 			Path: "/home/nils/projects/xyz/.git",
-			BranchTypes: map[string]BranchTypePattern{
-				"master":  `^(origin\/)*master`,
-				"feature": `^(origin\/)*feature/.*$`,
-				"develop": `^(origin\/)*develop$`,
-				"release": `^(origin\/)*release\/v([0-9]*\.*)*$`,
-				"hotfix":  `^(origin\/)*hotfix\/v([0-9]*\.*)*$`,
+			BranchTypes: BranchTypes{
+				{Name: "master", Pattern: `^(origin\/)*master`},
+				{Name: "feature", Pattern: `^(origin\/)*feature/.*$`},
+				{Name: "develop", Pattern: `^(origin\/)*develop$`},
+				{Name: "release", Pattern: `^(origin\/)*release\/v([0-9]*\.*)*$`},
+				{Name: "hotfix", Pattern: `^(origin\/)*hotfix\/v([0-9]*\.*)*$`},
 			},
 			Templates: map[string]BranchTypeTemplate{
 				"*": `{.BranchName}: {.CommitMessage}`,
@@ -43,6 +43,17 @@ func TestParse(t *testing.T) {
 					"(?m)@noissue": "@noissue",
 				},
 			},
+			Rules: map[string]ValidationRule{
+				"release": {
+					AllOf: []ValidationRule{
+						{Pattern: `(?m)(?:\s|^|/)(([A-Z](_)*)+-[0-9]+)([\s,;:!.-]|$)`, Description: "contain a ticket ID"},
+						{SubjectMaxLength: 72},
+					},
+					NoneOf: []ValidationRule{
+						{Pattern: "WIP", Description: "not contain WIP"},
+					},
+				},
+			},
 		},
 	}
 
@@ -54,6 +65,30 @@ func TestParse_InvalidTestData_ExpectUnmarshalError(t *testing.T) {
 	assert.Contains(t, err.Error(), "yaml: unmarshal errors")
 }
 
+func TestParseFromBytes_RuleWithoutCheck_ExpectError(t *testing.T) {
+	testDataSet := map[string]struct {
+		yaml          string
+		expectedError string
+	}{
+		"empty group": {
+			yaml:          "acme:\n  rules:\n    \"*\":\n      noneOf: []\n",
+			expectedError: "noneOf must hold at least one rule",
+		},
+		"rule with description only": {
+			yaml:          "acme:\n  rules:\n    \"*\":\n      noneOf:\n        - description: not contain WIP\n",
+			expectedError: "rule must have a pattern, a subjectMaxLength or a group of rules",
+		},
+	}
+
+	for testName, testData := range testDataSet {
+		t.Run(testName, func(t *testing.T) {
+			_, err := parseFromBytes([]byte(testData.yaml))
+
+			assert.EqualError(t, err, "project 'acme': rules of branch type '*': "+testData.expectedError)
+		})
+	}
+}
+
 func TestParse_FileNotFound_ExpectFileNotFoundError(t *testing.T) {
 	_, err := parse("test-data-which-does-not-exist.yaml")
 	assert.Contains(t, err.Error(), "no such file or directory")
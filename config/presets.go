@@ -0,0 +1,163 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// BuiltInPresets holds the presets every project can extend without defining them
+var BuiltInPresets = map[string]Project{
+	"gitflow": {
+		BranchTypes: BranchTypes{
+			{Name: "master", Pattern: `^(origin\/)*(master|main)$`},
+			{Name: "develop", Pattern: `^(origin\/)*develop$`},
+			{Name: "feature", Pattern: `^(origin\/)*feature\/.*$`},
+			{Name: "release", Pattern: `^(origin\/)*release\/.*$`},
+			{Name: "hotfix", Pattern: `^(origin\/)*hotfix\/.*$`},
+		},
+	},
+	"jira": {
+		Validation: map[string]BranchValidationConfiguration{
+			"*": {`(?m)(?:\s|^|/)(([A-Z](_)*)+-[0-9]+)([\s,;:!.-]|$)`: "valid ticket ID"},
+		},
+	},
+	"conventional": {
+		Conventional: map[string]ConventionalCommitConfiguration{
+			"*": {},
+		},
+	},
+}
+
+type presetResolver struct {
+	configuration Configuration
+	resolved      map[string]Project
+}
+
+// resolveExtends merges the presets every project extends into the project.
+func resolveExtends(configuration Configuration) error {
+	resolver := presetResolver{configuration: configuration, resolved: map[string]Project{}}
+
+	resolvedConfiguration := Configuration{}
+	for projectName := range configuration {
+		project, err := resolver.resolve(projectName, nil)
+		if err != nil {
+			return err
+		}
+		resolvedConfiguration[projectName] = project
+	}
+
+	for projectName, project := range resolvedConfiguration {
+		configuration[projectName] = project
+	}
+
+	return nil
+}
+
+// resolve returns the preset of the given name with all presets it extends merged into it.
+// The chain holds the presets that are currently resolved to detect cycles.
+func (r *presetResolver) resolve(presetName string, chain []string) (Project, error) {
+	if project, ok := r.resolved[presetName]; ok {
+		return project, nil
+	}
+
+	for _, name := range chain {
+		if name == presetName {
+			return Project{}, fmt.Errorf("cyclic extends: %s", strings.Join(append(chain, presetName), " -> "))
+		}
+	}
+
+	project, err := r.lookup(presetName, chain)
+	if err != nil {
+		return Project{}, err
+	}
+
+	chain = append(chain, presetName)
+
+	var resolved Project
+	for _, extendedPresetName := range project.Extends {
+		preset, err := r.resolve(extendedPresetName, chain)
+		if err != nil {
+			return Project{}, err
+		}
+		// a preset must not pull the project onto the repositories of the preset
+		preset.Path, preset.Remote, preset.Extends = "", "", nil
+		resolved = mergeProject(resolved, preset, presetName, ConfigurationLayer{}, nil)
+	}
+	resolved = mergeProject(resolved, project, presetName, ConfigurationLayer{}, nil)
+	resolved.Extends = project.Extends
+
+	r.resolved[presetName] = resolved
+
+	return resolved, nil
+}
+
+// lookup finds a preset by its name, which is a file, a project of the configuration or a built-in preset.
+func (r *presetResolver) lookup(presetName string, chain []string) (Project, error) {
+	if isPresetFile(presetName) {
+		return loadPresetFile(presetName)
+	}
+
+	if project, ok := r.configuration[presetName]; ok {
+		return project, nil
+	}
+
+	if project, ok := BuiltInPresets[presetName]; ok {
+		return project, nil
+	}
+
+	if len(chain) == 0 {
+		return Project{}, fmt.Errorf("unknown preset '%s'", presetName)
+	}
+
+	return Project{}, fmt.Errorf("unknown preset '%s' extended by '%s'", presetName, chain[len(chain)-1])
+}
+
+func isPresetFile(presetName string) bool {
+	switch strings.ToLower(filepath.Ext(presetName)) {
+	case ".yaml", ".yml", ".json", ".toml":
+		return true
+	default:
+		return false
+	}
+}
+
+// loadPresetFile reads a project from the given yaml, json or toml file. Files the project extends are relative
+// to the file.
+func loadPresetFile(filePath string) (Project, error) {
+	fileContent, err := readConfigurationFile(filePath)
+	if err != nil {
+		return Project{}, err
+	}
+
+	var project Project
+	err = decodeStrict(fileContent, &project)
+	if err == nil {
+		err = validateRules(project)
+	}
+	err = removeLineNumbers(err, GetConfigurationFormat(filePath))
+	if err != nil {
+		return Project{}, fmt.Errorf("error in preset file '%s': %v", filePath, err)
+	}
+
+	resolvePresetFilePaths(&project, filepath.Dir(filePath))
+
+	return project, nil
+}
+
+// resolvePresetFilePaths makes the preset files the project extends absolute, relative paths are resolved
+// against the given directory.
+func resolvePresetFilePaths(project *Project, dir string) {
+	if len(project.Extends) == 0 {
+		return
+	}
+
+	extends := make([]string, len(project.Extends))
+	for i, presetName := range project.Extends {
+		extends[i] = presetName
+		if isPresetFile(presetName) && !filepath.IsAbs(presetName) {
+			extends[i] = filepath.Join(dir, presetName)
+		}
+	}
+	project.Extends = extends
+}
@@ -0,0 +1,155 @@
+package config
+
+import (
+	"path"
+	"testing"
+
+	"github.com/Oppodelldog/git-commit-hook/testhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveExtends_BuiltInPresets(t *testing.T) {
+	configuration := Configuration{
+		"acme": Project{
+			Path:        "/home/dev/acme/.git",
+			Extends:     []string{"gitflow", "jira"},
+			BranchTypes: BranchTypes{{Name: "feature", Pattern: "^feat/.*$"}, {Name: "support", Pattern: "^support/.*$"}},
+			Templates:   map[string]BranchTypeTemplate{"feature": "{{.CommitMessage}}"},
+		},
+	}
+
+	err := resolveExtends(configuration)
+
+	assert.NoError(t, err)
+	assert.Exactly(t, Project{
+		Path:    "/home/dev/acme/.git",
+		Extends: []string{"gitflow", "jira"},
+		BranchTypes: BranchTypes{
+			{Name: "master", Pattern: `^(origin\/)*(master|main)$`},
+			{Name: "develop", Pattern: `^(origin\/)*develop$`},
+			{Name: "feature", Pattern: "^feat/.*$"},
+			{Name: "release", Pattern: `^(origin\/)*release\/.*$`},
+			{Name: "hotfix", Pattern: `^(origin\/)*hotfix\/.*$`},
+			{Name: "support", Pattern: "^support/.*$"},
+		},
+		Templates:  map[string]BranchTypeTemplate{"feature": "{{.CommitMessage}}"},
+		Validation: BuiltInPresets["jira"].Validation,
+	}, configuration["acme"])
+}
+
+func TestResolveExtends_ProjectsAsPresets(t *testing.T) {
+	configuration := Configuration{
+		"base": Project{
+			Templates: map[string]BranchTypeTemplate{"*": "{{.BranchName}}: {{.CommitMessage}}", "release": "release"},
+		},
+		"team": Project{
+			Path:      "/home/dev/team/.git",
+			Extends:   []string{"base"},
+			Templates: map[string]BranchTypeTemplate{"release": "{{.CommitMessage}}"},
+		},
+		"service": Project{
+			Remote:  "git@github.com:acme/service.git",
+			Extends: []string{"team"},
+		},
+	}
+
+	err := resolveExtends(configuration)
+
+	assert.NoError(t, err)
+	assert.Exactly(t, Project{
+		Remote:    "git@github.com:acme/service.git",
+		Extends:   []string{"team"},
+		Templates: map[string]BranchTypeTemplate{"*": "{{.BranchName}}: {{.CommitMessage}}", "release": "{{.CommitMessage}}"},
+	}, configuration["service"])
+	assert.Exactly(t, map[string]BranchTypeTemplate{"*": "{{.BranchName}}: {{.CommitMessage}}", "release": "release"}, configuration["base"].Templates)
+}
+
+func TestResolveExtends_Errors(t *testing.T) {
+	testCases := map[string]struct {
+		configuration Configuration
+		expectedError string
+	}{
+		"unknown preset": {
+			configuration: Configuration{"acme": Project{Extends: []string{"gitflow", "does-not-exist"}}},
+			expectedError: "unknown preset 'does-not-exist' extended by 'acme'",
+		},
+		"cycle": {
+			configuration: Configuration{
+				"a": Project{Extends: []string{"b"}},
+				"b": Project{Extends: []string{"a"}},
+			},
+			expectedError: "cyclic extends: ",
+		},
+		"preset file does not exist": {
+			configuration: Configuration{"acme": Project{Extends: []string{"/does/not/exist.yaml"}}},
+			expectedError: "open /does/not/exist.yaml: no such file or directory",
+		},
+	}
+
+	for testName, testData := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			err := resolveExtends(testData.configuration)
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), testData.expectedError)
+		})
+	}
+}
+
+func TestLoadConfigurationLayers_ExtendsFilesRelativeToConfigurationFile(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.CleanupTestEnvironment(t)
+	writeTestFiles(t, map[string]string{
+		"git-commit-hook.yaml": `
+acme:
+  path: /home/dev/acme/.git
+  extends: [presets/team.yaml]
+`,
+		"presets/team.yaml": `
+extends: [gitflow, ticket.yml]
+template:
+  "*": "{{.Branch.ticket}}: {{.CommitMessage}}"
+`,
+		"presets/ticket.yml": `
+branch:
+  - name: feature
+    pattern: "^feature/(?P<ticket>[A-Z]+-[0-9]+)$"
+`,
+	})
+	layer := ConfigurationLayer{Scope: ConfigurationScopeUser, FilePath: path.Join(testhelper.TestPath, "git-commit-hook.yaml")}
+
+	configuration, _, err := LoadConfigurationLayers([]ConfigurationLayer{layer})
+
+	assert.NoError(t, err)
+	assert.Exactly(t, []string{path.Join(testhelper.TestPath, "presets", "team.yaml")}, (*configuration)["acme"].Extends)
+	project := (*configuration)["acme"]
+	branchType, branchParts, err := project.GetBranchTypeMatch("feature/ACME-1")
+	assert.NoError(t, err)
+	assert.Exactly(t, "feature", branchType)
+	assert.Exactly(t, map[string]string{"ticket": "ACME-1"}, branchParts)
+	assertBranchType(t, &project, "develop", "develop")
+	assert.Exactly(t, BranchTypeTemplate("{{.Branch.ticket}}: {{.CommitMessage}}"), project.Templates["*"])
+}
+
+func TestLoadConfigurationLayers_ExtendsJSONAndTOMLFiles(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.CleanupTestEnvironment(t)
+	writeTestFiles(t, map[string]string{
+		"git-commit-hook.json": `{"acme": {"path": "/home/dev/acme/.git", "extends": ["presets/team.toml"]}}`,
+		"presets/team.toml": `
+extends = ["branches.json"]
+
+[template]
+"*" = "{{.Branch.ticket}}: {{.CommitMessage}}"
+`,
+		"presets/branches.json": `{"branch": [{"name": "feature", "pattern": "^feature/(?P<ticket>[A-Z]+-[0-9]+)$"}]}`,
+	})
+	layer := ConfigurationLayer{Scope: ConfigurationScopeUser, FilePath: path.Join(testhelper.TestPath, "git-commit-hook.json")}
+
+	configuration, _, err := LoadConfigurationLayers([]ConfigurationLayer{layer})
+
+	assert.NoError(t, err)
+	project := (*configuration)["acme"]
+	assert.Exactly(t, BranchTypes{{Name: "feature", Pattern: "^feature/(?P<ticket>[A-Z]+-[0-9]+)$"}}, project.BranchTypes)
+	assert.Exactly(t, BranchTypeTemplate("{{.Branch.ticket}}: {{.CommitMessage}}"), project.Templates["*"])
+}
@@ -1,34 +1,82 @@
 package config
 
 import (
+	"fmt"
+
 	"github.com/Oppodelldog/git-commit-hook/regexadapter"
 )
 
 type (
 	// Project defined a project related section of the Configuration
 	Project struct {
-		// Path to the git repository this configuration should be used while committing
+		// Name is the name the project is configured by, it is set when the project is looked up in the Configuration
+		Name string `yaml:"-"`
+		// Path to the git repository this configuration should be used while committing. It may start with '~',
+		// contain environment variables and the glob wildcards '*', '**' and '?' to cover several repositories.
 		Path string `yaml:"path"`
-		// BranchTypes is a map whose key defines a BranchType - it's value holds a pattern that identifies
-		// a given branch name to be of that branch type.
-		BranchTypes map[string]BranchTypePattern `yaml:"branch"`
+		// Remote is a pattern of the remote url of the git repositories this configuration should be used for,
+		// it may contain the glob wildcards '*', '**' and '?'. It is used if no project matches by Path.
+		Remote string `yaml:"remote,omitempty"`
+		// Extends lists presets whose configuration this project is based on. A preset is another project,
+		// a yaml file holding a project or a built-in preset. Later presets and the project itself override former ones.
+		Extends []string `yaml:"extends,omitempty"`
+		// RegexEngine is the engine that compiles the patterns of this project, 're2' (default) or 'pcre'.
+		// A single pattern may select its engine by a prefix like 'pcre:'.
+		RegexEngine string `yaml:"regexEngine,omitempty"`
+		// BranchTypes is an ordered list of branch types - each holds a pattern that identifies
+		// a given branch name to be of that branch type. The first matching branch type wins.
+		BranchTypes BranchTypes `yaml:"branch"`
 		// Templates is a map whose key refers a branchType - it's value holds a go template that will render the commit message
 		Templates map[string]BranchTypeTemplate `yaml:"template"`
+		// PrepareTemplates is a map whose key refers a branchType - it's value holds a go template that will be
+		// rendered into the editor by the prepare-commit-msg hook
+		PrepareTemplates map[string]BranchTypeTemplate `yaml:"prepare,omitempty"`
 		// Validation is a map whose key refers a branchType - it's value holds configuration to validate the created commit message
 		Validation map[string]BranchValidationConfiguration `yaml:"validation"`
+		// Rules is a map whose key refers a branchType - it's value holds a composed ValidationRule the created
+		// commit message must pass
+		Rules map[string]ValidationRule `yaml:"rules,omitempty"`
+		// Conventional is a map whose key refers a branchType - it's value enables conventional commit validation
+		Conventional map[string]ConventionalCommitConfiguration `yaml:"conventional,omitempty"`
+		// Trailers is a map whose key refers a branchType - it's value defines the trailers that are added to the
+		// commit message and the trailers it must have
+		Trailers map[string]TrailerConfiguration `yaml:"trailers,omitempty"`
+		// MessageKinds is a map whose key refers a kind of commit message (merge, squash, fixup, revert) - it's value
+		// defines how those messages are handled
+		MessageKinds map[string]MessageKindAction `yaml:"kinds,omitempty"`
+		// NoBranch defines what to do if the current branch cannot be determined
+		NoBranch NoBranchConfiguration `yaml:"noBranch,omitempty"`
 	}
 )
 
 // GetBranchType returns a branch type for the given branch name or empty string if no branch type was found.
-func (projConf *Project) GetBranchType(branchName string) string {
+// Branch types are tested by descending priority, then in the order they were defined. The first match wins.
+// If a branch type pattern is invalid, an error is returned.
+func (projConf *Project) GetBranchType(branchName string) (string, error) {
+	branchType, _, err := projConf.GetBranchTypeMatch(branchName)
+
+	return branchType, err
+}
 
-	for branchType, branchTypePattern := range projConf.BranchTypes {
-		if regexadapter.RegexMatchesString(string(branchTypePattern), branchName) {
-			return branchType
+// GetBranchTypeMatch works like GetBranchType, additionally it returns the values of the named capture groups
+// of the matching branch type pattern.
+// If the branch name is empty and a fallback branch type is configured, the fallback branch type is returned.
+func (projConf *Project) GetBranchTypeMatch(branchName string) (string, map[string]string, error) {
+	if branchName == "" && projConf.GetNoBranchAction() == NoBranchActionFallback {
+		return projConf.NoBranch.BranchType, map[string]string{}, nil
+	}
+
+	for _, branchType := range projConf.BranchTypes.Ordered() {
+		groups, err := regexadapter.RegexNamedGroups(projConf.RegexEngine, string(branchType.Pattern), branchName)
+		if err != nil {
+			return "", map[string]string{}, fmt.Errorf("branch type '%s': %v", branchType.Name, err)
+		}
+		if groups != nil {
+			return branchType.Name, groups, nil
 		}
 	}
 
-	return ""
+	return "", map[string]string{}, nil
 }
 
 // GetValidator returns the validator that matches the given branch type
@@ -43,3 +91,41 @@ func (projConf *Project) GetValidator(branchType string) map[string]string {
 
 	return foundValidators
 }
+
+// GetValidationRule returns the validation rule that matches the given branch type.
+// If there is no rule for the branch type the fallback rule (*) is returned. If there is none at all, false is returned.
+func (projConf *Project) GetValidationRule(branchType string) (ValidationRule, bool) {
+	if rule, ok := projConf.Rules[branchType]; ok {
+		return rule, true
+	}
+
+	rule, ok := projConf.Rules["*"]
+
+	return rule, ok
+}
+
+// GetConventionalCommitConfiguration returns the conventional commit configuration that matches the given branch type.
+// If there is no configuration for the branch type the fallback configuration (*) is returned.
+// If there is none at all, false is returned.
+func (projConf *Project) GetConventionalCommitConfiguration(branchType string) (ConventionalCommitConfiguration, bool) {
+	if conventionalConfig, ok := projConf.Conventional[branchType]; ok {
+		return conventionalConfig, true
+	}
+
+	conventionalConfig, ok := projConf.Conventional["*"]
+
+	return conventionalConfig, ok
+}
+
+// GetTrailerConfiguration returns the trailer configuration that matches the given branch type.
+// If there is no configuration for the branch type the fallback configuration (*) is returned.
+// If there is none at all, false is returned.
+func (projConf *Project) GetTrailerConfiguration(branchType string) (TrailerConfiguration, bool) {
+	if trailerConfig, ok := projConf.Trailers[branchType]; ok {
+		return trailerConfig, true
+	}
+
+	trailerConfig, ok := projConf.Trailers["*"]
+
+	return trailerConfig, ok
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,18 +18,173 @@ func TestGetBranchType(t *testing.T) {
 	}
 
 	for k, testData := range testDataSet {
-		t.Run(string(k), func(t *testing.T) {
+		t.Run(strconv.Itoa(k), func(t *testing.T) {
 
 			cfg := &Project{
-				BranchTypes: map[string]BranchTypePattern{
-					"feature": `^(origin\/)*feature/.*$`,
-					"release": `^(origin\/)*release\/v([0-9]*\.*)*(-fix)*$`,
+				BranchTypes: BranchTypes{
+					{Name: "feature", Pattern: `^(origin\/)*feature/.*$`},
+					{Name: "release", Pattern: `^(origin\/)*release\/v([0-9]*\.*)*(-fix)*$`},
 				},
 			}
 
-			branchType := cfg.GetBranchType(testData.BranchName)
+			branchType, err := cfg.GetBranchType(testData.BranchName)
 
+			assert.NoError(t, err)
 			assert.Exactly(t, testData.ExpectedBranchType, branchType)
 		})
 	}
 }
+
+func TestGetBranchType_OverlappingPatterns_FirstMatchWins(t *testing.T) {
+	cfg := &Project{
+		BranchTypes: BranchTypes{
+			{Name: "feature", Pattern: `^feature/.*$`},
+			{Name: "any", Pattern: `.*`},
+		},
+	}
+
+	for i := 0; i < 100; i++ {
+		assertBranchType(t, cfg, "feature/PROJECT-123", "feature")
+		assertBranchType(t, cfg, "develop", "any")
+	}
+}
+
+func TestGetBranchType_HigherPriorityWins(t *testing.T) {
+	cfg := &Project{
+		BranchTypes: BranchTypes{
+			{Name: "any", Pattern: `.*`},
+			{Name: "hotfix", Pattern: `^hotfix/.*$`, Priority: 1},
+		},
+	}
+
+	assertBranchType(t, cfg, "hotfix/v1.0.1", "hotfix")
+	assertBranchType(t, cfg, "develop", "any")
+}
+
+func TestGetBranchType_InvalidPattern_ReturnsError(t *testing.T) {
+	cfg := &Project{
+		BranchTypes: BranchTypes{
+			{Name: "feature", Pattern: `^feature/(.*$`},
+		},
+	}
+
+	branchType, err := cfg.GetBranchType("feature/PROJECT-123")
+
+	assert.Exactly(t, "", branchType)
+	assert.EqualError(t, err, "branch type 'feature': invalid pattern '^feature/(.*$': error parsing regexp: missing closing ): `^feature/(.*$`")
+}
+
+func TestGetBranchType_RegexEngine(t *testing.T) {
+	cfg := &Project{
+		RegexEngine: "pcre",
+		BranchTypes: BranchTypes{
+			{Name: "feature", Pattern: `^feature/(?!WIP)(?P<ticket>.*)$`},
+			{Name: "wip", Pattern: `re2:^feature/WIP.*$`},
+		},
+	}
+
+	branchType, groups, err := cfg.GetBranchTypeMatch("feature/PROJECT-123")
+	assert.NoError(t, err)
+	assert.Exactly(t, "feature", branchType)
+	assert.Exactly(t, map[string]string{"ticket": "PROJECT-123"}, groups)
+
+	assertBranchType(t, cfg, "feature/WIP-PROJECT-123", "wip")
+}
+
+func TestGetValidationRule(t *testing.T) {
+	cfg := &Project{
+		Rules: map[string]ValidationRule{
+			"release": {Description: "release rule"},
+			"*":       {Description: "fallback rule"},
+		},
+	}
+
+	rule, ok := cfg.GetValidationRule("release")
+	assert.True(t, ok)
+	assert.Exactly(t, "release rule", rule.Description)
+
+	rule, ok = cfg.GetValidationRule("feature")
+	assert.True(t, ok)
+	assert.Exactly(t, "fallback rule", rule.Description)
+}
+
+func TestGetValidationRule_NoRules(t *testing.T) {
+	cfg := &Project{}
+
+	_, ok := cfg.GetValidationRule("release")
+
+	assert.False(t, ok)
+}
+
+func TestGetConventionalCommitConfiguration(t *testing.T) {
+	cfg := &Project{
+		Conventional: map[string]ConventionalCommitConfiguration{
+			"release": {Types: []string{"fix"}},
+			"*":       {},
+		},
+	}
+
+	conventionalConfig, ok := cfg.GetConventionalCommitConfiguration("release")
+	assert.True(t, ok)
+	assert.Exactly(t, []string{"fix"}, conventionalConfig.AllowedTypes())
+
+	conventionalConfig, ok = cfg.GetConventionalCommitConfiguration("feature")
+	assert.True(t, ok)
+	assert.Exactly(t, DefaultConventionalCommitTypes, conventionalConfig.AllowedTypes())
+
+	_, ok = (&Project{}).GetConventionalCommitConfiguration("feature")
+	assert.False(t, ok)
+}
+
+func TestGetTrailerConfiguration(t *testing.T) {
+	cfg := &Project{
+		Trailers: map[string]TrailerConfiguration{
+			"release": {Require: []string{"Reviewed-by"}},
+			"*":       {Require: []string{"Refs"}},
+		},
+	}
+
+	trailerConfig, ok := cfg.GetTrailerConfiguration("release")
+	assert.True(t, ok)
+	assert.Exactly(t, []string{"Reviewed-by"}, trailerConfig.Require)
+
+	trailerConfig, ok = cfg.GetTrailerConfiguration("feature")
+	assert.True(t, ok)
+	assert.Exactly(t, []string{"Refs"}, trailerConfig.Require)
+
+	_, ok = (&Project{}).GetTrailerConfiguration("feature")
+	assert.False(t, ok)
+}
+
+func TestGetBranchTypeMatch(t *testing.T) {
+	cfg := &Project{
+		BranchTypes: BranchTypes{
+			{Name: "feature", Pattern: `^feature/(?P<ticket>[A-Z]+-[0-9]+)(-.*)?$`},
+			{Name: "release", Pattern: `^release/.*$`},
+		},
+	}
+
+	branchType, groups, err := cfg.GetBranchTypeMatch("feature/PROJECT-123-add-login")
+	assert.NoError(t, err)
+	assert.Exactly(t, "feature", branchType)
+	assert.Exactly(t, map[string]string{"ticket": "PROJECT-123"}, groups)
+
+	branchType, groups, err = cfg.GetBranchTypeMatch("release/v1.0.0")
+	assert.NoError(t, err)
+	assert.Exactly(t, "release", branchType)
+	assert.Exactly(t, map[string]string{}, groups)
+
+	branchType, groups, err = cfg.GetBranchTypeMatch("develop")
+	assert.NoError(t, err)
+	assert.Exactly(t, "", branchType)
+	assert.Exactly(t, map[string]string{}, groups)
+}
+
+func assertBranchType(t *testing.T, cfg *Project, branchName string, expectedBranchType string) {
+	t.Helper()
+
+	branchType, err := cfg.GetBranchType(branchName)
+
+	assert.NoError(t, err)
+	assert.Exactly(t, expectedBranchType, branchType)
+}
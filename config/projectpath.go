@@ -0,0 +1,148 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const exactPathMatchSpecificity = int(^uint(0) >> 1)
+
+type (
+	getEnvFuncDef      func(key string) string
+	userHomeDirFuncDef func() (string, error)
+)
+
+var (
+	getEnvFunc      = getEnvFuncDef(os.Getenv)
+	userHomeDirFunc = userHomeDirFuncDef(os.UserHomeDir)
+)
+
+// matchProjectPath tells whether the given repository path is covered by the configured project path and how
+// specific the match is. The project path may start with '~', contain environment variables and the
+// glob wildcards '*', '**' and '?'. A project path ending with a path separator covers every path below it.
+// An exact match is the most specific one, otherwise the more literal characters a pattern has, the more specific it is.
+func matchProjectPath(projectPath string, repoPath string) (bool, int) {
+	if projectPath == "" {
+		return false, 0
+	}
+	if projectPath == repoPath {
+		return true, exactPathMatchSpecificity
+	}
+
+	pattern := expandProjectPath(projectPath)
+	if !isProjectPathPattern(pattern) {
+		if filepath.Clean(filepath.FromSlash(pattern)) == filepath.Clean(repoPath) {
+			return true, exactPathMatchSpecificity
+		}
+		return false, 0
+	}
+
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "**"
+	}
+
+	return matchGlob(pattern, filepath.ToSlash(repoPath))
+}
+
+// GetRepositoryPath returns the path of the repository the project is configured for, a leading '~' and environment
+// variables are expanded. If the path is a pattern that covers several repositories, false is returned.
+func (projConf *Project) GetRepositoryPath() (string, bool) {
+	repositoryPath := expandProjectPath(projConf.Path)
+	if isProjectPathPattern(repositoryPath) {
+		return "", false
+	}
+
+	return filepath.FromSlash(repositoryPath), true
+}
+
+// isProjectPathPattern tells whether the given expanded project path contains wildcards or ends with a path separator
+// and so covers several repositories.
+func isProjectPathPattern(expandedProjectPath string) bool {
+	return strings.ContainsAny(expandedProjectPath, "*?") || strings.HasSuffix(expandedProjectPath, "/")
+}
+
+// matchProjectPaths matches the configured project path against all given repository paths and returns the most
+// specific match. The rank is the index of the matching repository path, on equal specificity the earlier path wins.
+func matchProjectPaths(projectPath string, repoPaths []string) (bool, int, int) {
+	bestSpecificity, bestRank := -1, 0
+	for i, repoPath := range repoPaths {
+		if matches, specificity := matchProjectPath(projectPath, repoPath); matches && specificity > bestSpecificity {
+			bestSpecificity, bestRank = specificity, i
+		}
+	}
+
+	return bestSpecificity >= 0, bestSpecificity, bestRank
+}
+
+// matchProjectRemote tells whether one of the given remote urls is covered by the configured remote url pattern
+// and how specific the match is. The pattern may contain the glob wildcards '*', '**' and '?'.
+func matchProjectRemote(remotePattern string, remoteURLs []string) (bool, int) {
+	if remotePattern == "" {
+		return false, 0
+	}
+
+	for _, remoteURL := range remoteURLs {
+		if remotePattern == remoteURL {
+			return true, exactPathMatchSpecificity
+		}
+		if matches, specificity := matchGlob(remotePattern, remoteURL); matches {
+			return true, specificity
+		}
+	}
+
+	return false, 0
+}
+
+// matchGlob tells whether the glob pattern matches the given value. The specificity of the match
+// is the number of literal characters of the pattern.
+func matchGlob(pattern string, value string) (bool, int) {
+	re, err := regexp.Compile(globToRegex(pattern))
+	if err != nil || !re.MatchString(value) {
+		return false, 0
+	}
+
+	return true, len(strings.NewReplacer("*", "", "?", "").Replace(pattern))
+}
+
+// expandProjectPath replaces a leading '~' by the home directory and expands environment variables.
+func expandProjectPath(projectPath string) string {
+	expanded := os.Expand(projectPath, getEnvFunc)
+	if expanded == "~" || strings.HasPrefix(expanded, "~/") {
+		if homeDir, err := userHomeDirFunc(); err == nil {
+			expanded = homeDir + expanded[1:]
+		}
+	}
+
+	return filepath.ToSlash(expanded)
+}
+
+// globToRegex converts a glob pattern into a regular expression. '**' matches any number of path segments,
+// '*' matches any characters but the path separator and '?' matches a single character but the path separator.
+func globToRegex(pattern string) string {
+	var re strings.Builder
+	re.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "/**/"):
+			re.WriteString("(/.*)?/")
+			i += 3
+		case strings.HasPrefix(pattern[i:], "/**") && i+3 == len(pattern):
+			re.WriteString("(/.*)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			re.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			re.WriteString("[^/]*")
+		case pattern[i] == '?':
+			re.WriteString("[^/]")
+		default:
+			re.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	re.WriteString("$")
+
+	return re.String()
+}
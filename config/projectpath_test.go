@@ -0,0 +1,172 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchProjectPath(t *testing.T) {
+	defer restoreProjectPathOriginals()
+	getEnvFunc = func(key string) string {
+		return map[string]string{"WORK": "/home/dev/work"}[key]
+	}
+	userHomeDirFunc = func() (string, error) { return "/home/dev", nil }
+
+	testCases := map[string]struct {
+		projectPath     string
+		repoPath        string
+		expectedMatches bool
+	}{
+		"exact path":                        {projectPath: "/home/dev/work/acme/svc/.git", repoPath: "/home/dev/work/acme/svc/.git", expectedMatches: true},
+		"exact path, trailing separator":    {projectPath: "/home/dev/work/acme/svc/.git/", repoPath: "/home/dev/work/acme/svc/.git", expectedMatches: true},
+		"exact path does not cover subdirs": {projectPath: "/home/dev/work/acme", repoPath: "/home/dev/work/acme/svc/.git", expectedMatches: false},
+		"empty path":                        {projectPath: "", repoPath: "/home/dev/work/acme/svc/.git", expectedMatches: false},
+		"home dir":                          {projectPath: "~/work/acme/svc/.git", repoPath: "/home/dev/work/acme/svc/.git", expectedMatches: true},
+		"env var":                           {projectPath: "$WORK/acme/svc/.git", repoPath: "/home/dev/work/acme/svc/.git", expectedMatches: true},
+		"env var in braces":                 {projectPath: "${WORK}/acme/svc/.git", repoPath: "/home/dev/work/acme/svc/.git", expectedMatches: true},
+		"double star":                       {projectPath: "~/work/acme/**", repoPath: "/home/dev/work/acme/svc/.git", expectedMatches: true},
+		"double star, nested":               {projectPath: "~/work/acme/**", repoPath: "/home/dev/work/acme/group/svc/.git", expectedMatches: true},
+		"double star, other folder":         {projectPath: "~/work/acme/**", repoPath: "/home/dev/work/other/svc/.git", expectedMatches: false},
+		"double star, prefix of folder":     {projectPath: "~/work/acme/**", repoPath: "/home/dev/work/acme-legacy/svc/.git", expectedMatches: false},
+		"double star in between":            {projectPath: "~/work/**/.git", repoPath: "/home/dev/work/acme/svc/.git", expectedMatches: true},
+		"single star":                       {projectPath: "~/work/acme/*/.git", repoPath: "/home/dev/work/acme/svc/.git", expectedMatches: true},
+		"single star, one segment only":     {projectPath: "~/work/acme/*/.git", repoPath: "/home/dev/work/acme/group/svc/.git", expectedMatches: false},
+		"question mark":                     {projectPath: "~/work/acme/sv?/.git", repoPath: "/home/dev/work/acme/svc/.git", expectedMatches: true},
+		"prefix":                            {projectPath: "~/work/acme/", repoPath: "/home/dev/work/acme/svc/.git", expectedMatches: true},
+		"prefix, other folder":              {projectPath: "~/work/acme/", repoPath: "/home/dev/work/acme-legacy/.git", expectedMatches: false},
+		"regex characters are literal":      {projectPath: "~/work/a.me/**", repoPath: "/home/dev/work/acme/svc/.git", expectedMatches: false},
+	}
+
+	for testName, testData := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			matches, _ := matchProjectPath(testData.projectPath, testData.repoPath)
+
+			assert.Exactly(t, testData.expectedMatches, matches)
+		})
+	}
+}
+
+func TestMatchProjectPath_HomeDirCannotBeResolved(t *testing.T) {
+	defer restoreProjectPathOriginals()
+	userHomeDirFunc = func() (string, error) { return "", errors.New("no home") }
+
+	matches, _ := matchProjectPath("~/work/**", "/home/dev/work/acme/.git")
+
+	assert.False(t, matches)
+}
+
+func TestProject_GetRepositoryPath(t *testing.T) {
+	defer restoreProjectPathOriginals()
+	getEnvFunc = func(key string) string {
+		return map[string]string{"WORK": "/home/dev/work"}[key]
+	}
+	userHomeDirFunc = func() (string, error) { return "/home/dev", nil }
+
+	testCases := map[string]struct {
+		projectPath        string
+		expectedPath       string
+		expectedIsConcrete bool
+	}{
+		"exact path":    {projectPath: "/home/dev/work/acme/.git", expectedPath: "/home/dev/work/acme/.git", expectedIsConcrete: true},
+		"home dir":      {projectPath: "~/work/acme/.git", expectedPath: "/home/dev/work/acme/.git", expectedIsConcrete: true},
+		"env var":       {projectPath: "$WORK/acme/.git", expectedPath: "/home/dev/work/acme/.git", expectedIsConcrete: true},
+		"double star":   {projectPath: "~/work/acme/**"},
+		"single star":   {projectPath: "$WORK/*/.git"},
+		"prefix":        {projectPath: "~/work/acme/"},
+		"question mark": {projectPath: "/home/dev/work/acme/sv?/.git"},
+	}
+
+	for testName, testData := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			repositoryPath, isConcrete := (&Project{Path: testData.projectPath}).GetRepositoryPath()
+
+			assert.Exactly(t, testData.expectedPath, repositoryPath)
+			assert.Exactly(t, testData.expectedIsConcrete, isConcrete)
+		})
+	}
+}
+
+func TestGetProjectByRepoPath_MostSpecificMatchWins(t *testing.T) {
+	defer restoreProjectPathOriginals()
+	userHomeDirFunc = func() (string, error) { return "/home/dev", nil }
+
+	cfg := Configuration{
+		"work":    Project{Path: "~/work/**"},
+		"acme":    Project{Path: "~/work/acme/**"},
+		"billing": Project{Path: "~/work/acme/billing/.git"},
+		"legacy":  Project{Path: "~/work/acme/legacy-*/.git"},
+	}
+
+	testCases := map[string]struct {
+		repoPath            string
+		expectedProjectName string
+	}{
+		"exact match":                  {repoPath: "/home/dev/work/acme/billing/.git", expectedProjectName: "billing"},
+		"longer pattern wins":          {repoPath: "/home/dev/work/acme/legacy-app/.git", expectedProjectName: "legacy"},
+		"shorter pattern if no longer": {repoPath: "/home/dev/work/acme/shop/.git", expectedProjectName: "acme"},
+		"broadest pattern":             {repoPath: "/home/dev/work/private/.git", expectedProjectName: "work"},
+	}
+
+	for testName, testData := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			projectCfg, err := cfg.GetProjectByRepoPath(testData.repoPath)
+
+			assert.NoError(t, err)
+			assert.Exactly(t, cfg.getProject(testData.expectedProjectName), projectCfg)
+		})
+	}
+}
+
+func TestGetProjectByRemoteURLs(t *testing.T) {
+	cfg := Configuration{
+		"by path": Project{Path: "/home/dev/work/acme/billing/.git"},
+		"acme":    Project{Remote: "git@github.com:acme/*"},
+		"billing": Project{Remote: "git@github.com:acme/billing.git"},
+		"gitlab":  Project{Remote: "https://gitlab.acme.com/**"},
+	}
+
+	testCases := map[string]struct {
+		remoteURLs          []string
+		expectedProjectName string
+	}{
+		"exact match wins":         {remoteURLs: []string{"git@github.com:acme/billing.git"}, expectedProjectName: "billing"},
+		"pattern":                  {remoteURLs: []string{"git@github.com:acme/shop.git"}, expectedProjectName: "acme"},
+		"double star pattern":      {remoteURLs: []string{"https://gitlab.acme.com/group/sub/shop.git"}, expectedProjectName: "gitlab"},
+		"any remote of repository": {remoteURLs: []string{"git@github.com:dev/shop.git", "git@github.com:acme/shop.git"}, expectedProjectName: "acme"},
+	}
+
+	for testName, testData := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			projectCfg, err := cfg.GetProjectByRemoteURLs(testData.remoteURLs...)
+
+			assert.NoError(t, err)
+			assert.Exactly(t, cfg.getProject(testData.expectedProjectName), projectCfg)
+		})
+	}
+}
+
+func TestGetProjectByRemoteURLs_NotFound(t *testing.T) {
+	cfg := Configuration{
+		"acme": Project{Remote: "git@github.com:acme/*"},
+		"none": Project{},
+	}
+
+	_, err := cfg.GetProjectByRemoteURLs("git@github.com:acme/group/shop.git", "git@github.com:other/shop.git")
+
+	assert.EqualError(t, err, "project configuration not found for remote 'git@github.com:acme/group/shop.git', 'git@github.com:other/shop.git'")
+}
+
+var projectPathOriginals = struct {
+	getEnvFunc      getEnvFuncDef
+	userHomeDirFunc userHomeDirFuncDef
+}{
+	getEnvFunc:      getEnvFunc,
+	userHomeDirFunc: userHomeDirFunc,
+}
+
+func restoreProjectPathOriginals() {
+	getEnvFunc = projectPathOriginals.getEnvFunc
+	userHomeDirFunc = projectPathOriginals.userHomeDirFunc
+}
@@ -0,0 +1,21 @@
+package config
+
+type (
+	// TrailerConfiguration defines the trailers of the commit messages of a branch type, like 'Refs: PROJECT-123'
+	TrailerConfiguration struct {
+		// Add lists trailers that are appended to the commit message, unless it already has a trailer of the same
+		// key and value
+		Add []TrailerTemplate `yaml:"add,omitempty"`
+		// Require lists the keys of the trailers a commit message must have
+		Require []string `yaml:"require,omitempty"`
+	}
+
+	// TrailerTemplate defines a trailer that is added to the commit message
+	TrailerTemplate struct {
+		// Key of the trailer, like 'Signed-off-by'
+		Key string `yaml:"key"`
+		// Value is a go template that is rendered like the commit message template.
+		// If it renders to an empty string, the trailer is not added.
+		Value BranchTypeTemplate `yaml:"value"`
+	}
+)
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+type (
+	// ValidationRule defines a composable validation rule for commit messages.
+	// A rule passes if all of its own checks pass, all AllOf rules pass, at least one of the AnyOf rules passes
+	// and none of the NoneOf rules passes. Rules may be nested to any depth.
+	ValidationRule struct {
+		// Description is shown to the user if the rule fails, for NoneOf rules it tells what the message must not do
+		Description string `yaml:"description,omitempty"`
+		// Pattern is a regex pattern the commit message must match
+		Pattern string `yaml:"pattern,omitempty"`
+		// SubjectMaxLength limits the number of characters of the first line of the commit message
+		SubjectMaxLength int `yaml:"subjectMaxLength,omitempty"`
+		// AllOf holds rules that all must pass
+		AllOf []ValidationRule `yaml:"allOf,omitempty"`
+		// AnyOf holds rules of which at least one must pass
+		AnyOf []ValidationRule `yaml:"anyOf,omitempty"`
+		// NoneOf holds rules of which none may pass
+		NoneOf []ValidationRule `yaml:"noneOf,omitempty"`
+	}
+)
+
+// errRuleWithoutCheck is returned for rules that check nothing, they pass every message, or fail every message as
+// part of noneOf
+var errRuleWithoutCheck = errors.New("rule must have a pattern, a subjectMaxLength or a group of rules")
+
+type ruleGroup struct {
+	name  string
+	rules []ValidationRule
+}
+
+// groups returns the nested rules of the rule by the yaml key of their group.
+func (r ValidationRule) groups() []ruleGroup {
+	return []ruleGroup{{"allOf", r.AllOf}, {"anyOf", r.AnyOf}, {"noneOf", r.NoneOf}}
+}
+
+// validate reports a rule that checks nothing, like an empty group of rules. Nested rules are not validated.
+// The yaml key of the empty group is returned along with its error.
+func (r ValidationRule) validate() (string, error) {
+	for _, group := range r.groups() {
+		if group.rules != nil && len(group.rules) == 0 {
+			return group.name, emptyRuleGroupError(group.name)
+		}
+	}
+
+	if r.Pattern == "" && r.SubjectMaxLength <= 0 && len(r.AllOf)+len(r.AnyOf)+len(r.NoneOf) == 0 {
+		return "", errRuleWithoutCheck
+	}
+
+	return "", nil
+}
+
+// validateAll validates the rule and all of its nested rules.
+func (r ValidationRule) validateAll() error {
+	if _, err := r.validate(); err != nil {
+		return err
+	}
+
+	for _, group := range r.groups() {
+		for _, rule := range group.rules {
+			if err := rule.validateAll(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateRules reports the first rule of the given project that checks nothing.
+func validateRules(project Project) error {
+	branchTypes := make([]string, 0, len(project.Rules))
+	for branchType := range project.Rules {
+		branchTypes = append(branchTypes, branchType)
+	}
+	sort.Strings(branchTypes)
+
+	for _, branchType := range branchTypes {
+		if err := project.Rules[branchType].validateAll(); err != nil {
+			return fmt.Errorf("rules of branch type '%s': %v", branchType, err)
+		}
+	}
+
+	return nil
+}
+
+func emptyRuleGroupError(groupName string) error {
+	return fmt.Errorf("%s must hold at least one rule", groupName)
+}
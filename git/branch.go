@@ -1,16 +1,35 @@
 package git
 
 import (
+	"io/ioutil"
 	"os"
-	"os/exec"
-	"regexp"
+	"path/filepath"
+	"strings"
 )
 
-type execFuncDef func(s1 string, s2 ...string) *exec.Cmd
+const headsRefPrefix = "refs/heads/"
 
-var execFunc = execFuncDef(exec.Command)
+const (
+	// StateRebasing means a rebase is in progress
+	StateRebasing = "rebasing"
+	// StateBisecting means a bisect is in progress
+	StateBisecting = "bisecting"
+)
+
+// Head describes what the HEAD of a repository points to
+type Head struct {
+	// BranchName is the name of the checked out branch. If HEAD is detached by a rebase or bisect it is
+	// the branch being rebased or bisected, otherwise it is empty if HEAD is detached.
+	BranchName string
+	// Detached is true if HEAD points to a commit instead of a branch
+	Detached bool
+	// CommitSHA is the commit HEAD points to, it is empty for a branch without commits
+	CommitSHA string
+	// State tells if a rebase or bisect is in progress, it is empty otherwise
+	State string
+}
 
-//GetCurrentBranchName executes 'git branch' to get the current branch
+//GetCurrentBranchName reads the current branch from the repository of the working dir
 func GetCurrentBranchName() (string, error) {
 	wd, err := os.Getwd()
 	if err != nil {
@@ -19,51 +38,100 @@ func GetCurrentBranchName() (string, error) {
 	return GetCurrentBranchNameFromDirectory(wd)
 }
 
-//GetCurrentBranchName executes 'git branch' to get the current branch in the given directory
+//GetHead reads HEAD of the repository of the working dir
+func GetHead() (Head, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return Head{}, err
+	}
+	return GetHeadFromDirectory(wd)
+}
+
+//GetCurrentBranchNameFromDirectory reads the current branch from the repository of the given directory.
+// If HEAD is detached an empty branch name is returned.
 func GetCurrentBranchNameFromDirectory(directory string) (string, error) {
-	cmd := execFunc("git", "branch")
-	cmd.Dir = directory
-	outputBytes, err := cmd.CombinedOutput()
+	head, err := GetHeadFromDirectory(directory)
 	if err != nil {
 		return "", err
 	}
 
-	branchName := getBranchNameFromGitOutput(string(outputBytes))
-
-	if branchName == "" {
-		outputBytes, err := execFunc("git", "log").CombinedOutput()
-		if err != nil {
-			return "", err
-		}
+	return head.BranchName, nil
+}
 
-		branchName = getBranchNameFromGitLogOutput(string(outputBytes))
+//GetHeadFromDirectory reads HEAD of the repository of the given directory
+func GetHeadFromDirectory(directory string) (Head, error) {
+	gitDir, err := FindGitDir(directory)
+	if err != nil {
+		return Head{}, err
 	}
 
-	return branchName, nil
+	return readHead(gitDir)
 }
 
-func getBranchNameFromGitOutput(gitOutput string) string {
+func readHead(gitDir string) (Head, error) {
+	content, err := ioutil.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return Head{}, err
+	}
+
+	headValue := strings.TrimSpace(string(content))
+	if !strings.HasPrefix(headValue, "ref: ") {
+		head := Head{Detached: true, CommitSHA: headValue}
+		head.BranchName, head.State = recoverDetachedBranch(gitDir)
+
+		return head, nil
+	}
+
+	ref := strings.TrimPrefix(headValue, "ref: ")
 
-	return extractFromString(gitOutput, `(?m)^\* (.*)$`)
+	return Head{
+		BranchName: strings.TrimPrefix(ref, headsRefPrefix),
+		CommitSHA:  resolveRef(gitDir, ref),
+	}, nil
 }
 
-func getBranchNameFromGitLogOutput(gitOutput string) string {
-	// https://github.com/git/git/blob/ed843436dd4924c10669820cc73daf50f0b4dabd/revision.c#L2303
-	pattern := `(?m)^fatal: your current branch '(.*)' does not have any commits yet$`
+// recoverDetachedBranch finds the branch a rebase or bisect was started on
+func recoverDetachedBranch(gitDir string) (branchName string, state string) {
+	for _, rebaseDir := range []string{"rebase-merge", "rebase-apply"} {
+		headName, err := ioutil.ReadFile(filepath.Join(gitDir, rebaseDir, "head-name"))
+		if err == nil {
+			return toBranchName(string(headName)), StateRebasing
+		}
+	}
+
+	bisectStart, err := ioutil.ReadFile(filepath.Join(gitDir, "BISECT_START"))
+	if err == nil {
+		return toBranchName(string(bisectStart)), StateBisecting
+	}
 
-	return extractFromString(gitOutput, pattern)
+	return "", ""
 }
 
-func extractFromString(s, regexPattern string) string {
-	var re = regexp.MustCompile(regexPattern)
+// toBranchName returns the branch name of a ref stored in git state files, like 'refs/heads/feature/x'.
+// Commit SHAs and 'detached HEAD' do not name a branch and result in an empty string.
+func toBranchName(storedRef string) string {
+	storedRef = strings.TrimSpace(storedRef)
+	if strings.HasPrefix(storedRef, headsRefPrefix) {
+		return strings.TrimPrefix(storedRef, headsRefPrefix)
+	}
+
+	if storedRef == "detached HEAD" || isCommitSHA(storedRef) {
+		return ""
+	}
 
-	matches := re.FindAllStringSubmatch(s, 1)
+	return storedRef
+}
+
+func isCommitSHA(s string) bool {
+	if len(s) != 40 && len(s) != 64 {
+		return false
+	}
 
-	if len(matches) > 0 {
-		if len(matches[0]) > 1 {
-			return matches[0][1]
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
 		}
 	}
 
-	return ""
+	return true
 }
@@ -1,110 +1,212 @@
 package git
 
 import (
-	"os/exec"
+	"io/ioutil"
+	"os"
+	"path"
 	"testing"
 
+	"github.com/Oppodelldog/git-commit-hook/testhelper"
 	"github.com/stretchr/testify/assert"
 )
 
+const testSHA = "8a5b9b6c3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b"
+
 var originals = struct {
-	execFunc execFuncDef
+	execFunc   execFuncDef
+	getEnvFunc getEnvFuncDef
 }{
-	execFunc: execFunc,
+	execFunc:   execFunc,
+	getEnvFunc: getEnvFunc,
 }
 
 func restoreOriginals() {
 	execFunc = originals.execFunc
+	getEnvFunc = originals.getEnvFunc
 }
 
-func TestGetCurrentBranchName_FindsBranchInCommandOutput(t *testing.T) {
-	defer restoreOriginals()
+func TestGetCurrentBranchName_ReadsBranchOfGitRepository(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	testhelper.InitGitRepository(t, "feature/PROJECT-123")
+
+	branchName, err := GetCurrentBranchName()
+
+	assert.NoError(t, err)
+	assert.Exactly(t, "feature/PROJECT-123", branchName)
+}
+
+func TestGetCurrentBranchName_NoGitRepository_ReturnsError(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
 
-	testCases := []struct {
-		gitBranchOutput    string
-		gitLogOutput       string
-		expectedBranchName string
+	_, err := GetCurrentBranchName()
+
+	assert.Contains(t, err.Error(), "not a git repository")
+}
+
+func TestGetHeadFromDirectory(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+
+	testCases := map[string]struct {
+		files        map[string]string
+		expectedHead Head
 	}{
-		{gitBranchOutput: `develop`, expectedBranchName: ""},
-		{gitBranchOutput: `* develop`, expectedBranchName: "develop"},
-		{gitBranchOutput: "* develop\nmaster", expectedBranchName: "develop"},
-		{gitBranchOutput: "release/v1.0.1\n* develop\nmaster", expectedBranchName: "develop"},
-		{gitBranchOutput: "feature/xyz\ndevelop\n* release/v1.0.1\nmaster", expectedBranchName: "release/v1.0.1"},
-		{gitBranchOutput: ``, gitLogOutput: `fatal: your current branch 'master' does not have any commits yet`, expectedBranchName: "master"},
+		"branch with loose ref": {
+			files: map[string]string{
+				"HEAD":                     "ref: refs/heads/develop\n",
+				"refs/heads/develop":       testSHA + "\n",
+				"refs/heads/feature/other": "0000000000000000000000000000000000000000\n",
+			},
+			expectedHead: Head{BranchName: "develop", CommitSHA: testSHA},
+		},
+		"branch with packed ref": {
+			files: map[string]string{
+				"HEAD":        "ref: refs/heads/release/v1.0.0\n",
+				"packed-refs": "# pack-refs with: peeled fully-peeled sorted\n" + testSHA + " refs/heads/release/v1.0.0\n^0000000000000000000000000000000000000000\n",
+			},
+			expectedHead: Head{BranchName: "release/v1.0.0", CommitSHA: testSHA},
+		},
+		"branch without commits": {
+			files: map[string]string{
+				"HEAD": "ref: refs/heads/master\n",
+			},
+			expectedHead: Head{BranchName: "master"},
+		},
+		"detached head": {
+			files: map[string]string{
+				"HEAD": testSHA + "\n",
+			},
+			expectedHead: Head{Detached: true, CommitSHA: testSHA},
+		},
+		"interactive rebase": {
+			files: map[string]string{
+				"HEAD":                   testSHA + "\n",
+				"rebase-merge/head-name": "refs/heads/feature/X\n",
+			},
+			expectedHead: Head{BranchName: "feature/X", Detached: true, CommitSHA: testSHA, State: StateRebasing},
+		},
+		"rebase by apply": {
+			files: map[string]string{
+				"HEAD":                   testSHA + "\n",
+				"rebase-apply/head-name": "refs/heads/develop\n",
+			},
+			expectedHead: Head{BranchName: "develop", Detached: true, CommitSHA: testSHA, State: StateRebasing},
+		},
+		"rebase of detached head": {
+			files: map[string]string{
+				"HEAD":                   testSHA + "\n",
+				"rebase-merge/head-name": "detached HEAD\n",
+			},
+			expectedHead: Head{Detached: true, CommitSHA: testSHA, State: StateRebasing},
+		},
+		"bisect": {
+			files: map[string]string{
+				"HEAD":         testSHA + "\n",
+				"BISECT_START": "release/v1.0.0\n",
+			},
+			expectedHead: Head{BranchName: "release/v1.0.0", Detached: true, CommitSHA: testSHA, State: StateBisecting},
+		},
+		"bisect started on detached head": {
+			files: map[string]string{
+				"HEAD":         testSHA + "\n",
+				"BISECT_START": testSHA + "\n",
+			},
+			expectedHead: Head{Detached: true, CommitSHA: testSHA, State: StateBisecting},
+		},
 	}
 
-	for i, testCase := range testCases {
-		t.Run(string(i), func(t *testing.T) {
-			execFunc = createExecCommandMock(t, testCase.gitBranchOutput, testCase.gitLogOutput)
-			branchName, err := GetCurrentBranchName()
+	for testCaseName, testData := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			testhelper.InitTestFolder(t)
+			writeFiles(t, testhelper.TestPathGitFolder, testData.files)
+			writeFiles(t, testhelper.TestPathGitFolder, map[string]string{"objects/.keep": ""})
+
+			head, err := GetHeadFromDirectory(path.Join(testhelper.TestPath, "some", "sub", "dir"))
+
 			assert.NoError(t, err)
-			assert.Exactly(t, testCase.expectedBranchName, branchName)
+			assert.Exactly(t, testData.expectedHead, head)
 		})
 	}
 }
 
-func createExecCommandMock(t *testing.T, gitBranchOutput, gitLogOutput string) execFuncDef {
-	var callCounter int
-	return func(s1 string, s2 ...string) *exec.Cmd {
-		callCounter++
-		if s1 != "git" {
-			t.Fatalf("expected 'git' to be executed, but got %v", s1)
-		}
-		switch callCounter {
-		case 1:
-			if s2[0] != "branch" {
-				t.Fatalf("expected 'branch' to be parameter of git command, but got %v", s2[0])
-			}
-			return exec.Command("echo", gitBranchOutput)
-		case 2:
-			if s2[0] != "log" {
-				t.Fatalf("expected 'branch' to be parameter of git command, but got %v", s2[0])
-			}
-			return exec.Command("echo", gitLogOutput)
-		}
-		t.Fatalf("not expected to be called that often! (%v times)", callCounter)
-		return nil
-	}
+func TestGetCurrentBranchName_DuringInteractiveRebase(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	testhelper.InitGitRepository(t, "feature/X")
+	writeFiles(t, testhelper.TestPath, map[string]string{"second.md": "second"})
+	testhelper.Git(t, "add", "-A")
+	testhelper.Git(t, "commit", "-m", "second commit")
+	testhelper.Git(t, "-c", "sequence.editor=sed -i.bak -e 's/^pick/edit/'", "rebase", "-i", "HEAD~1")
+
+	branchName, err := GetCurrentBranchName()
+
+	assert.NoError(t, err)
+	assert.Exactly(t, "feature/X", branchName)
 }
 
-func TestGetCurrentBranchName_ExpectGitCommandIsCalledProperly(t *testing.T) {
-	defer restoreOriginals()
+func TestGetHeadFromDirectory_LinkedWorktree(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
 
-	execFunc = func(s1 string, s2 ...string) *exec.Cmd {
-		assert.Exactly(t, "git", s1)
-		assert.Exactly(t, []string{"branch"}, s2)
+	writeFiles(t, testhelper.TestPath, map[string]string{
+		"main/.git/objects/.keep":                 "",
+		"main/.git/HEAD":                          "ref: refs/heads/develop\n",
+		"main/.git/refs/heads/feature/x":          testSHA + "\n",
+		"main/.git/worktrees/linked/HEAD":         "ref: refs/heads/feature/x\n",
+		"main/.git/worktrees/linked/commondir":    "../..\n",
+		"linked/.git":                             "gitdir: ../main/.git/worktrees/linked\n",
+		"linked/some-file-in-the-linked-worktree": "",
+	})
 
-		return exec.Command("", "")
-	}
+	head, err := GetHeadFromDirectory(path.Join(testhelper.TestPath, "linked"))
 
-	GetCurrentBranchName()
+	assert.NoError(t, err)
+	assert.Exactly(t, Head{BranchName: "feature/x", CommitSHA: testSHA}, head)
 }
 
-func TestGetCurrentBranchName_ReturnsErrorFromOneOfBothPossibleCommandExecutions(t *testing.T) {
+func TestGetHeadFromDirectory_GitDirEnvironmentVariable(t *testing.T) {
 	defer restoreOriginals()
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+
+	writeFiles(t, testhelper.TestPath, map[string]string{
+		"elsewhere/HEAD": "ref: refs/heads/from-env\n",
+	})
+	getEnvFunc = func(key string) string {
+		if key == "GIT_DIR" {
+			return path.Join(testhelper.TestPath, "elsewhere")
+		}
+		return ""
+	}
 
-	for i := 1; i <= 2; i++ {
-		t.Run(string(i), func(t *testing.T) {
-			execFunc = createExecFuncErrorStub(i)
+	head, err := GetHeadFromDirectory("/")
 
-			_, err := GetCurrentBranchName()
-			assert.Error(t, err)
-		})
-	}
+	assert.NoError(t, err)
+	assert.Exactly(t, "from-env", head.BranchName)
 }
 
-func createExecFuncErrorStub(errorCommandAtCall int) execFuncDef {
-	var counter int
-	return func(s1 string, s2 ...string) *exec.Cmd {
-		counter++
-		if counter == errorCommandAtCall {
-			return exec.Command("thiscommandwillnotbefound", "")
-		}
-		//noinspection SpellCheckingInspection
-		return exec.Command("echo", "works")
-	}
+func TestFindGitDir_InvalidGitDirFile(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	writeFiles(t, testhelper.TestPath, map[string]string{".git": "something else"})
+
+	_, err := FindGitDir(testhelper.TestPath)
+
+	assert.Contains(t, err.Error(), "invalid gitdir file")
 }
 
-func TestDefaultExecFuncIsExecCommand(t *testing.T) {
-	assert.IsType(t, execFuncDef(exec.Command), execFunc)
+func writeFiles(t *testing.T, baseDir string, files map[string]string) {
+	t.Helper()
+	for fileName, content := range files {
+		filePath := path.Join(baseDir, fileName)
+		err := os.MkdirAll(path.Dir(filePath), 0777)
+		if err != nil {
+			t.Fatalf("Did not expect os.MkdirAll to return an error, but got: %v ", err)
+		}
+		err = ioutil.WriteFile(filePath, []byte(content), 0666)
+		if err != nil {
+			t.Fatalf("Did not expect ioutil.WriteFile to return an error, but got: %v ", err)
+		}
+	}
 }
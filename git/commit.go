@@ -0,0 +1,98 @@
+package git
+
+import (
+	"errors"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var identPattern = regexp.MustCompile(`^(.*) <(.*)> (\d+) ([+-]\d{4})$`)
+
+// Author is the author of the commit being created
+type Author struct {
+	Name  string
+	Email string
+	// Date is the author date, it honors GIT_AUTHOR_DATE
+	Date time.Time
+}
+
+// GetAuthor executes 'git var GIT_AUTHOR_IDENT' to read the author of the commit being created.
+// It honors the user config and the GIT_AUTHOR_NAME, GIT_AUTHOR_EMAIL and GIT_AUTHOR_DATE environment variables.
+func GetAuthor() (Author, error) {
+	outputBytes, err := execFunc("git", "var", "GIT_AUTHOR_IDENT").Output()
+	if err != nil {
+		return Author{}, err
+	}
+
+	return parseIdent(strings.TrimRight(string(outputBytes), "\r\n"))
+}
+
+// parseIdent parses an ident like 'Jane Doe <jane@example.com> 1700000000 +0100'
+func parseIdent(ident string) (Author, error) {
+	matches := identPattern.FindStringSubmatch(ident)
+	if matches == nil {
+		return Author{}, errors.New("invalid ident: " + ident)
+	}
+
+	seconds, err := strconv.ParseInt(matches[3], 10, 64)
+	if err != nil {
+		return Author{}, err
+	}
+	zone, err := time.Parse("-0700", matches[4])
+	if err != nil {
+		return Author{}, err
+	}
+
+	return Author{
+		Name:  matches[1],
+		Email: matches[2],
+		Date:  time.Unix(seconds, 0).In(zone.Location()),
+	}, nil
+}
+
+// GetUpstreamBranchName executes 'git rev-parse' to read the upstream of the current branch, like 'origin/develop'.
+// If the branch has no upstream or git fails, an empty string is returned.
+func GetUpstreamBranchName() string {
+	outputBytes, err := execFunc("git", "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}").Output()
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimRight(string(outputBytes), "\r\n")
+}
+
+// GetStagedFiles executes 'git diff --cached' to read the paths of the staged files relative to the worktree root.
+// If nothing is staged or git fails, nil is returned.
+func GetStagedFiles() []string {
+	outputBytes, err := execFunc("git", "diff", "--cached", "--name-only", "-z").Output()
+	if err != nil {
+		return nil
+	}
+
+	var stagedFiles []string
+	for _, stagedFile := range strings.Split(string(outputBytes), "\x00") {
+		if stagedFile != "" {
+			stagedFiles = append(stagedFiles, stagedFile)
+		}
+	}
+
+	return stagedFiles
+}
+
+// GetRepositoryName returns the name of the repository the given git dir belongs to. This is the name of the main
+// worktree, so linked worktrees share the name of their repository, bare repositories are named without '.git'.
+func GetRepositoryName(gitDir string) string {
+	commonDir := GetCommonDir(gitDir)
+	if filepath.Base(commonDir) == ".git" {
+		return filepath.Base(filepath.Dir(commonDir))
+	}
+
+	if worktree := readCoreWorktree(commonDir); worktree != "" {
+		return filepath.Base(resolvePath(commonDir, worktree))
+	}
+
+	return strings.TrimSuffix(filepath.Base(commonDir), ".git")
+}
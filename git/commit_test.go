@@ -0,0 +1,102 @@
+package git
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/Oppodelldog/git-commit-hook/testhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAuthor(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	defer os.Unsetenv("GIT_AUTHOR_DATE")
+	testhelper.InitTestFolder(t)
+	testhelper.InitGitRepository(t, "develop")
+	testhelper.Git(t, "config", "user.name", "Jane Doe")
+	testhelper.Git(t, "config", "user.email", "jane@example.com")
+	os.Setenv("GIT_AUTHOR_DATE", "1700000000 +0100")
+
+	author, err := GetAuthor()
+
+	assert.NoError(t, err)
+	assert.Exactly(t, "Jane Doe", author.Name)
+	assert.Exactly(t, "jane@example.com", author.Email)
+	assert.Exactly(t, "2023-11-14T23:13:20+01:00", author.Date.Format(time.RFC3339))
+}
+
+func TestParseIdent_Invalid(t *testing.T) {
+	_, err := parseIdent("Jane Doe jane@example.com")
+
+	assert.EqualError(t, err, "invalid ident: Jane Doe jane@example.com")
+}
+
+func TestGetUpstreamBranchName(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	testhelper.InitGitRepository(t, "develop")
+
+	assert.Exactly(t, "", GetUpstreamBranchName())
+
+	testhelper.Git(t, "branch", "--set-upstream-to", "master")
+
+	assert.Exactly(t, "master", GetUpstreamBranchName())
+}
+
+func TestGetStagedFiles(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	testhelper.InitGitRepository(t, "develop")
+
+	assert.Nil(t, GetStagedFiles())
+
+	writeFiles(t, testhelper.TestPath, map[string]string{
+		"svc-auth/main.go":     "package main",
+		"svc-auth/api/user.go": "package api",
+		"README.md":            "readme",
+		"not staged.txt":       "",
+	})
+	testhelper.Git(t, "add", "svc-auth", "README.md")
+
+	assert.Exactly(t, []string{"README.md", "svc-auth/api/user.go", "svc-auth/main.go"}, GetStagedFiles())
+}
+
+func TestGetRepositoryName(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	writeFiles(t, testhelper.TestPath, map[string]string{
+		"main/.git/HEAD":                       "ref: refs/heads/master\n",
+		"main/.git/worktrees/linked/commondir": "../..\n",
+		"main/.git/modules/sub/config":         "[core]\n\tworktree = ../../../sub\n",
+		"main/.git/modules/sub/HEAD":           "ref: refs/heads/master\n",
+		"main/.git/modules/sub/objects/.keep":  "",
+		"main/.git/modules/sub/refs/.keep":     "",
+		"bare.git/HEAD":                        "ref: refs/heads/master\n",
+	})
+
+	testCases := map[string]struct {
+		gitDir       string
+		expectedName string
+	}{
+		"main worktree":   {gitDir: path.Join(testhelper.TestPath, "main", ".git"), expectedName: "main"},
+		"linked worktree": {gitDir: path.Join(testhelper.TestPath, "main", ".git", "worktrees", "linked"), expectedName: "main"},
+		"submodule":       {gitDir: path.Join(testhelper.TestPath, "main", ".git", "modules", "sub"), expectedName: "sub"},
+		"bare":            {gitDir: path.Join(testhelper.TestPath, "bare.git"), expectedName: "bare"},
+	}
+
+	for testCaseName, testCase := range testCases {
+		t.Run(testCaseName, func(t *testing.T) {
+			assert.Exactly(t, testCase.expectedName, GetRepositoryName(testCase.gitDir))
+		})
+	}
+}
+
+func TestGetStagedFiles_NoRepository(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+
+	assert.Nil(t, GetStagedFiles())
+	assert.Exactly(t, "", GetUpstreamBranchName())
+}
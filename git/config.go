@@ -0,0 +1,80 @@
+package git
+
+import (
+	"os/exec"
+	"strings"
+)
+
+type execFuncDef func(s1 string, s2 ...string) *exec.Cmd
+
+var execFunc = execFuncDef(exec.Command)
+
+//GetConfigValue executes 'git config --get' to read the value of the given key.
+// If the key is not set or git fails, an empty string is returned.
+func GetConfigValue(key string) string {
+	return getConfigValue("", "--get", key)
+}
+
+// ConfigValue is a key value pair read by 'git config'. The key is lower case except of the subsection,
+// like in 'remote.Origin.url'.
+type ConfigValue struct {
+	Key   string
+	Value string
+}
+
+// GetConfigValues executes 'git config --get-regexp' to read all keys matching the given regular expression.
+// The values are returned in the order git reads them: system, global, then local config. Keys that are set several
+// times are returned several times. If no key matches or git fails, nil is returned.
+func GetConfigValues(keyPattern string) []ConfigValue {
+	return getConfigValues("", "--get-regexp", keyPattern)
+}
+
+// getConfigValue executes 'git config' with the given arguments for the repository of the given git dir, an empty
+// git dir is the repository of the working directory. If git fails, an empty string is returned.
+func getConfigValue(gitDir string, args ...string) string {
+	outputBytes, err := execGitConfig(gitDir, args...).Output()
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimRight(string(outputBytes), "\r\n")
+}
+
+// getConfigValues executes 'git config --null' with the given arguments, which list keys, for the repository of the
+// given git dir, an empty git dir is the repository of the working directory. If git fails, nil is returned.
+func getConfigValues(gitDir string, args ...string) []ConfigValue {
+	outputBytes, err := execGitConfig(gitDir, append([]string{"--null"}, args...)...).Output()
+	if err != nil {
+		return nil
+	}
+
+	var values []ConfigValue
+	for _, entry := range strings.Split(string(outputBytes), "\x00") {
+		if entry == "" {
+			continue
+		}
+		// a key without value, like a boolean, is not followed by a newline
+		parts := strings.SplitN(entry, "\n", 2)
+		value := ConfigValue{Key: parts[0]}
+		if len(parts) == 2 {
+			value.Value = parts[1]
+		}
+		values = append(values, value)
+	}
+
+	return values
+}
+
+func execGitConfig(gitDir string, args ...string) *exec.Cmd {
+	return execGit(gitDir, append([]string{"config"}, args...)...)
+}
+
+// execGit creates a git command for the repository of the given git dir, an empty git dir is the repository of the
+// working directory.
+func execGit(gitDir string, args ...string) *exec.Cmd {
+	if gitDir != "" {
+		args = append([]string{"--git-dir", gitDir}, args...)
+	}
+
+	return execFunc("git", args...)
+}
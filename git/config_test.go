@@ -0,0 +1,41 @@
+package git
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/Oppodelldog/git-commit-hook/testhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetConfigValue(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	testhelper.InitGitRepository(t, "develop")
+	testhelper.Git(t, "config", "core.commentChar", ";")
+
+	assert.Exactly(t, ";", GetConfigValue("core.commentChar"))
+	assert.Exactly(t, "", GetConfigValue("commit.doesnotexist"))
+}
+
+func TestGetConfigValues(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	testhelper.InitGitRepository(t, "develop")
+	testhelper.Git(t, "config", "commithook.branch.feature", "^feature/.*$")
+	testhelper.Git(t, "config", "--add", "commithook.extends", "gitflow")
+	testhelper.Git(t, "config", "--add", "commithook.extends", "jira")
+	testhelper.Git(t, "config", "commithook.Template.feature", "{{.BranchName}}:\n{{.CommitMessage}}")
+
+	assert.Exactly(t, []ConfigValue{
+		{Key: "commithook.branch.feature", Value: "^feature/.*$"},
+		{Key: "commithook.extends", Value: "gitflow"},
+		{Key: "commithook.extends", Value: "jira"},
+		{Key: "commithook.Template.feature", Value: "{{.BranchName}}:\n{{.CommitMessage}}"},
+	}, GetConfigValues(`^commithook\.`))
+	assert.Nil(t, GetConfigValues(`^doesnotexist\.`))
+}
+
+func TestDefaultExecFuncIsExecCommand(t *testing.T) {
+	assert.IsType(t, execFuncDef(exec.Command), execFunc)
+}
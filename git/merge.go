@@ -0,0 +1,24 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+)
+
+//IsMergeInProgress checks if the repository in the current working directory has a MERGE_HEAD, which means
+// that the commit being created is a merge commit.
+func IsMergeInProgress() bool {
+	wd, err := os.Getwd()
+	if err != nil {
+		return false
+	}
+
+	gitDir, err := FindGitDir(wd)
+	if err != nil {
+		return false
+	}
+
+	_, err = os.Stat(filepath.Join(gitDir, "MERGE_HEAD"))
+
+	return err == nil
+}
@@ -0,0 +1,31 @@
+package git
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/Oppodelldog/git-commit-hook/testhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsMergeInProgress(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	testhelper.InitGitRepository(t, "develop")
+
+	assert.False(t, IsMergeInProgress())
+
+	err := ioutil.WriteFile(".git/MERGE_HEAD", []byte("0000000000000000000000000000000000000000\n"), 0666)
+	if err != nil {
+		t.Fatalf("Did not expect ioutil.WriteFile to return an error, but got: %v ", err)
+	}
+
+	assert.True(t, IsMergeInProgress())
+}
+
+func TestIsMergeInProgress_NoGitRepository(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+
+	assert.False(t, IsMergeInProgress())
+}
@@ -0,0 +1,42 @@
+package git
+
+import (
+	"sort"
+	"strings"
+)
+
+const defaultRemoteName = "origin"
+
+// GetRemoteURLs reads the urls of all remotes of the repository the given git dir belongs to the way git resolves
+// them, so 'url.<base>.insteadOf' is applied. The urls of the remote 'origin' come first, the others follow in the
+// order git reads them.
+func GetRemoteURLs(gitDir string) []string {
+	var remoteNames []string
+	seen := map[string]bool{}
+	for _, value := range getConfigValues(gitDir, "--get-regexp", `^remote\..*\.url$`) {
+		remoteName := strings.TrimSuffix(strings.TrimPrefix(value.Key, "remote."), ".url")
+		if !seen[remoteName] {
+			seen[remoteName] = true
+			remoteNames = append(remoteNames, remoteName)
+		}
+	}
+
+	sort.SliceStable(remoteNames, func(i, j int) bool {
+		return remoteNames[i] == defaultRemoteName && remoteNames[j] != defaultRemoteName
+	})
+
+	urls := []string{}
+	for _, remoteName := range remoteNames {
+		outputBytes, err := execGit(gitDir, "remote", "get-url", "--all", remoteName).Output()
+		if err != nil {
+			continue
+		}
+		for _, url := range strings.Split(strings.TrimRight(string(outputBytes), "\r\n"), "\n") {
+			if url != "" {
+				urls = append(urls, url)
+			}
+		}
+	}
+
+	return urls
+}
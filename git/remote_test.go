@@ -0,0 +1,62 @@
+package git
+
+import (
+	"path"
+	"testing"
+
+	"github.com/Oppodelldog/git-commit-hook/testhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRemoteURLs(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	testhelper.InitGitRepository(t, "develop")
+	testhelper.Git(t, "remote", "add", "upstream", "https://github.com/acme/billing.git")
+	testhelper.Git(t, "remote", "add", "origin", "git@github.com:dev/billing.git")
+
+	remoteURLs := GetRemoteURLs(path.Join(testhelper.TestPath, ".git"))
+
+	assert.Exactly(t, []string{"git@github.com:dev/billing.git", "https://github.com/acme/billing.git"}, remoteURLs)
+}
+
+func TestGetRemoteURLs_LinkedWorktreeReadsCommonConfig(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	writeFiles(t, testhelper.TestPath, map[string]string{
+		"main/.git/HEAD":                       "ref: refs/heads/master\n",
+		"main/.git/objects/.keep":              "",
+		"main/.git/refs/.keep":                 "",
+		"main/.git/config":                     "[core]\n\tbare = false\n[remote \"origin\"]\n\turl = git@github.com:acme/billing.git\n\tfetch = +refs/heads/*:refs/remotes/origin/*\n",
+		"main/.git/worktrees/linked/HEAD":      "ref: refs/heads/feature/x\n",
+		"main/.git/worktrees/linked/commondir": "../..\n",
+	})
+
+	remoteURLs := GetRemoteURLs(path.Join(testhelper.TestPath, "main", ".git", "worktrees", "linked"))
+
+	assert.Exactly(t, []string{"git@github.com:acme/billing.git"}, remoteURLs)
+}
+
+func TestGetRemoteURLs_ResolvedLikeGit(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	writeFiles(t, testhelper.TestPath, map[string]string{
+		"main/.git/HEAD":          "ref: refs/heads/master\n",
+		"main/.git/objects/.keep": "",
+		"main/.git/refs/.keep":    "",
+		"main/.git/config":        "[core]\n\tbare = false\n[include]\n\tpath = remotes.inc\n",
+		"main/.git/remotes.inc":   "[remote \"origin\"]\n\turl = \"gh:acme/billing.git\" ; inline comment\n[url \"https://github.com/\"]\n\tinsteadOf = gh:\n",
+	})
+
+	remoteURLs := GetRemoteURLs(path.Join(testhelper.TestPath, "main", ".git"))
+
+	assert.Exactly(t, []string{"https://github.com/acme/billing.git"}, remoteURLs)
+}
+
+func TestGetRemoteURLs_NoRemotes(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	testhelper.InitGitRepository(t, "develop")
+
+	assert.Empty(t, GetRemoteURLs(path.Join(testhelper.TestPath, ".git")))
+}
@@ -0,0 +1,207 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type getEnvFuncDef func(key string) string
+
+var getEnvFunc = getEnvFuncDef(os.Getenv)
+
+// FindGitDir returns the git dir of the repository the given directory belongs to.
+// It honors GIT_DIR and resolves '.git' files of linked worktrees and submodules.
+func FindGitDir(directory string) (string, error) {
+	if gitDir := getEnvFunc("GIT_DIR"); gitDir != "" {
+		return filepath.Abs(gitDir)
+	}
+
+	dir, err := filepath.Abs(directory)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		dotGit := filepath.Join(dir, ".git")
+		if info, err := os.Stat(dotGit); err == nil {
+			if info.IsDir() {
+				return dotGit, nil
+			}
+			return readGitDirFile(dotGit)
+		}
+
+		if isGitDir(dir) {
+			return dir, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("not a git repository (or any of the parent directories): %s", directory)
+		}
+		dir = parent
+	}
+}
+
+// GetCommonDir returns the directory that holds the shared data of all worktrees of a repository, like refs
+// and config. For the main worktree this is the git dir itself.
+func GetCommonDir(gitDir string) string {
+	content, err := ioutil.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		return gitDir
+	}
+
+	return resolvePath(gitDir, strings.TrimSpace(string(content)))
+}
+
+// RepositoryPaths holds the paths a repository may be identified by
+type RepositoryPaths struct {
+	// GitDir is the git dir of the worktree, e.g. '.git/worktrees/<name>' for a linked worktree
+	GitDir string
+	// CommonDir is the git dir shared by all worktrees of the repository
+	CommonDir string
+	// WorktreeRoot is the top level directory of the checked out files, it is empty for bare repositories
+	WorktreeRoot string
+	// SuperprojectGitDirs lists the git dirs of the repositories the repository is a submodule of, innermost first
+	SuperprojectGitDirs []string
+}
+
+// GetRepositoryPaths resolves the common dir, the worktree root and the superprojects of the given git dir.
+func GetRepositoryPaths(gitDir string) RepositoryPaths {
+	commonDir := GetCommonDir(gitDir)
+
+	return RepositoryPaths{
+		GitDir:              gitDir,
+		CommonDir:           commonDir,
+		WorktreeRoot:        getWorktreeRoot(gitDir, commonDir),
+		SuperprojectGitDirs: getSuperprojectGitDirs(commonDir),
+	}
+}
+
+// getWorktreeRoot resolves the top level directory of a worktree. Linked worktrees point back to their '.git' file
+// by the 'gitdir' file, submodules configure 'core.worktree', otherwise the worktree is the parent of the '.git' dir.
+func getWorktreeRoot(gitDir, commonDir string) string {
+	if content, err := ioutil.ReadFile(filepath.Join(gitDir, "gitdir")); err == nil {
+		return filepath.Dir(resolvePath(gitDir, strings.TrimSpace(string(content))))
+	}
+
+	if worktree := readCoreWorktree(commonDir); worktree != "" {
+		return resolvePath(commonDir, worktree)
+	}
+
+	if filepath.Base(commonDir) == ".git" {
+		return filepath.Dir(commonDir)
+	}
+
+	return ""
+}
+
+// readCoreWorktree reads 'core.worktree' of the repository config of the given git dir by git config, so includes,
+// quoting and comments are resolved like git does.
+func readCoreWorktree(gitDir string) string {
+	return getConfigValue(gitDir, "--local", "--includes", "--get", "core.worktree")
+}
+
+// getSuperprojectGitDirs walks up the 'modules' folders submodule git dirs are stored in.
+func getSuperprojectGitDirs(commonDir string) []string {
+	var superprojectGitDirs []string
+	modulesSeparator := string(filepath.Separator) + "modules" + string(filepath.Separator)
+	dir := commonDir
+	for {
+		i := strings.LastIndex(dir, modulesSeparator)
+		if i <= 0 {
+			return superprojectGitDirs
+		}
+		dir = dir[:i]
+		if isGitDir(dir) {
+			superprojectGitDirs = append(superprojectGitDirs, dir)
+		}
+	}
+}
+
+func readGitDirFile(dotGitFile string) (string, error) {
+	content, err := ioutil.ReadFile(dotGitFile)
+	if err != nil {
+		return "", err
+	}
+
+	line := strings.TrimSpace(string(content))
+	if !strings.HasPrefix(line, "gitdir: ") {
+		return "", fmt.Errorf("invalid gitdir file: %s", dotGitFile)
+	}
+
+	return resolvePath(filepath.Dir(dotGitFile), strings.TrimPrefix(line, "gitdir: ")), nil
+}
+
+func isGitDir(dir string) bool {
+	if _, err := os.Stat(filepath.Join(dir, "HEAD")); err != nil {
+		return false
+	}
+	if _, err := os.Stat(filepath.Join(dir, "commondir")); err == nil {
+		return true
+	}
+	_, err := os.Stat(filepath.Join(dir, "objects"))
+
+	return err == nil
+}
+
+func resolvePath(baseDir, path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+
+	return filepath.Join(baseDir, path)
+}
+
+// resolveRef returns the commit SHA the given ref points to. Per worktree refs are looked up in the git dir,
+// shared refs in the common dir, finally packed-refs are searched. Unborn branches resolve to an empty string.
+func resolveRef(gitDir, ref string) string {
+	for depth := 0; depth < 5; depth++ {
+		value := readLooseRef(gitDir, ref)
+		if value == "" {
+			return readPackedRef(GetCommonDir(gitDir), ref)
+		}
+		if !strings.HasPrefix(value, "ref: ") {
+			return value
+		}
+		ref = strings.TrimPrefix(value, "ref: ")
+	}
+
+	return ""
+}
+
+func readLooseRef(gitDir, ref string) string {
+	for _, dir := range []string{gitDir, GetCommonDir(gitDir)} {
+		content, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(ref)))
+		if err == nil {
+			return strings.TrimSpace(string(content))
+		}
+	}
+
+	return ""
+}
+
+func readPackedRef(commonDir, ref string) string {
+	file, err := os.Open(filepath.Join(commonDir, "packed-refs"))
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "^") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[1] == ref {
+			return fields[0]
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,100 @@
+package git
+
+import (
+	"path"
+	"testing"
+
+	"github.com/Oppodelldog/git-commit-hook/testhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRepositoryPaths(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	writeFiles(t, testhelper.TestPath, map[string]string{
+		"main/.git/HEAD":                                     "ref: refs/heads/master\n",
+		"main/.git/objects/.keep":                            "",
+		"main/.git/worktrees/linked/HEAD":                    "ref: refs/heads/feature/x\n",
+		"main/.git/worktrees/linked/commondir":               "../..\n",
+		"main/.git/worktrees/linked/gitdir":                  path.Join(testhelper.TestPath, "linked", ".git") + "\n",
+		"main/.git/modules/sub/HEAD":                         testSHA + "\n",
+		"main/.git/modules/sub/objects/.keep":                "",
+		"main/.git/modules/sub/refs/.keep":                   "",
+		"main/.git/modules/sub/config":                       "[core]\n\tbare = false\n\tworktree = ../../../sub\n",
+		"main/.git/modules/sub/modules/nested/HEAD":          testSHA + "\n",
+		"main/.git/modules/sub/modules/nested/config":        "[core]\n\tworktree = ../../../../../sub/nested\n",
+		"main/.git/modules/sub/modules/nested/objects/.keep": "",
+		"main/.git/modules/sub/modules/nested/refs/.keep":    "",
+		"linked/.git":            "gitdir: ../main/.git/worktrees/linked\n",
+		"bare.git/HEAD":          "ref: refs/heads/master\n",
+		"bare.git/objects/.keep": "",
+	})
+	mainGitDir := path.Join(testhelper.TestPath, "main", ".git")
+
+	testCases := map[string]struct {
+		gitDir        string
+		expectedPaths RepositoryPaths
+	}{
+		"main worktree": {
+			gitDir: mainGitDir,
+			expectedPaths: RepositoryPaths{
+				GitDir:       mainGitDir,
+				CommonDir:    mainGitDir,
+				WorktreeRoot: path.Join(testhelper.TestPath, "main"),
+			},
+		},
+		"linked worktree": {
+			gitDir: path.Join(mainGitDir, "worktrees", "linked"),
+			expectedPaths: RepositoryPaths{
+				GitDir:       path.Join(mainGitDir, "worktrees", "linked"),
+				CommonDir:    mainGitDir,
+				WorktreeRoot: path.Join(testhelper.TestPath, "linked"),
+			},
+		},
+		"submodule": {
+			gitDir: path.Join(mainGitDir, "modules", "sub"),
+			expectedPaths: RepositoryPaths{
+				GitDir:              path.Join(mainGitDir, "modules", "sub"),
+				CommonDir:           path.Join(mainGitDir, "modules", "sub"),
+				WorktreeRoot:        path.Join(testhelper.TestPath, "main", "sub"),
+				SuperprojectGitDirs: []string{mainGitDir},
+			},
+		},
+		"nested submodule": {
+			gitDir: path.Join(mainGitDir, "modules", "sub", "modules", "nested"),
+			expectedPaths: RepositoryPaths{
+				GitDir:              path.Join(mainGitDir, "modules", "sub", "modules", "nested"),
+				CommonDir:           path.Join(mainGitDir, "modules", "sub", "modules", "nested"),
+				WorktreeRoot:        path.Join(testhelper.TestPath, "main", "sub", "nested"),
+				SuperprojectGitDirs: []string{path.Join(mainGitDir, "modules", "sub"), mainGitDir},
+			},
+		},
+		"bare repository": {
+			gitDir: path.Join(testhelper.TestPath, "bare.git"),
+			expectedPaths: RepositoryPaths{
+				GitDir:    path.Join(testhelper.TestPath, "bare.git"),
+				CommonDir: path.Join(testhelper.TestPath, "bare.git"),
+			},
+		},
+	}
+
+	for testName, testData := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			assert.Exactly(t, testData.expectedPaths, GetRepositoryPaths(testData.gitDir))
+		})
+	}
+}
+
+func TestReadCoreWorktree_ResolvedLikeGit(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.InitTestFolder(t)
+	writeFiles(t, testhelper.TestPath, map[string]string{
+		"sub/HEAD":          "ref: refs/heads/master\n",
+		"sub/objects/.keep": "",
+		"sub/refs/.keep":    "",
+		"sub/config":        "[include]\n\tpath = worktree.inc\n",
+		"sub/worktree.inc":  "[core]\n\tworktree = \"../../sub dir\" # inline comment\n",
+	})
+
+	assert.Exactly(t, "../../sub dir", readCoreWorktree(path.Join(testhelper.TestPath, "sub")))
+}
@@ -3,11 +3,14 @@ module github.com/Oppodelldog/git-commit-hook
 go 1.13
 
 require (
+	github.com/BurntSushi/toml v1.3.2
 	github.com/Oppodelldog/filediscovery v0.3.0
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/dlclark/regexp2 v1.11.4
 	github.com/kr/pretty v0.1.0 // indirect
 	github.com/pkg/errors v0.8.1
 	github.com/stretchr/testify v1.4.0
 	gopkg.in/check.v1 v1.0.0-20190902080502-41f04d3bba15 // indirect
-	gopkg.in/yaml.v2 v2.2.2
+	gopkg.in/yaml.v2 v2.2.2 // indirect
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,145 @@
+package hook
+
+import (
+	"os"
+	"strings"
+
+	"github.com/Oppodelldog/git-commit-hook/git"
+)
+
+// CleanupMode defines how a commit message is cleaned up, it follows the modes of 'git commit --cleanup'
+type CleanupMode string
+
+const (
+	// CleanupModeStrip strips leading and trailing empty lines, trailing whitespace and commentary. Everything below the
+	// scissors line is removed only if git put the diff of 'git commit --verbose' there
+	CleanupModeStrip CleanupMode = "strip"
+	// CleanupModeWhitespace works like CleanupModeStrip, but keeps commentary
+	CleanupModeWhitespace CleanupMode = "whitespace"
+	// CleanupModeVerbatim does not change the message at all
+	CleanupModeVerbatim CleanupMode = "verbatim"
+	// CleanupModeScissors works like CleanupModeWhitespace, but removes everything below the scissors line
+	CleanupModeScissors CleanupMode = "scissors"
+
+	defaultCommentChar = "#"
+	scissorsLine       = "------------------------ >8 ------------------------"
+)
+
+type (
+	getGitConfigValueFuncDef func(key string) string
+	getEnvFuncDef            func(key string) string
+)
+
+var (
+	getGitConfigValueFunc = getGitConfigValueFuncDef(git.GetConfigValue)
+	getEnvFunc            = getEnvFuncDef(os.Getenv)
+)
+
+// cleanupCommitMessageByGitSettings cleans up the commit message the way git will do it
+// after the hook ran. It honors 'commit.cleanup' and 'core.commentChar'.
+func cleanupCommitMessageByGitSettings(commitMessage string) string {
+	return cleanupCommitMessage(commitMessage, getCleanupMode(), getCommentChar())
+}
+
+func getCleanupMode() CleanupMode {
+	cleanupMode := CleanupMode(getGitConfigValueFunc("commit.cleanup"))
+	if cleanupMode != "" && cleanupMode != "default" {
+		return cleanupMode
+	}
+
+	// git sets GIT_EDITOR=: for hooks if no editor was used, in this case git only cleans whitespace
+	if getEnvFunc("GIT_EDITOR") == ":" {
+		return CleanupModeWhitespace
+	}
+
+	return CleanupModeStrip
+}
+
+func getCommentChar() string {
+	commentChar := getGitConfigValueFunc("core.commentChar")
+	if commentChar == "" || commentChar == "auto" {
+		return defaultCommentChar
+	}
+
+	return commentChar
+}
+
+func cleanupCommitMessage(commitMessage string, cleanupMode CleanupMode, commentChar string) string {
+	switch cleanupMode {
+	case CleanupModeVerbatim:
+		return commitMessage
+	case CleanupModeWhitespace:
+		return stripSpace(commitMessage, "")
+	case CleanupModeScissors:
+		return stripSpace(cutAtScissorsLine(commitMessage, commentChar), "")
+	default:
+		if isVerboseCommitMessage(commitMessage, commentChar) {
+			commitMessage = cutAtScissorsLine(commitMessage, commentChar)
+		}
+		return stripSpace(commitMessage, commentChar)
+	}
+}
+
+func cutAtScissorsLine(commitMessage string, commentChar string) string {
+	lines := strings.Split(commitMessage, "\n")
+	if i := findScissorsLine(lines, commentChar); i >= 0 {
+		return strings.Join(lines[:i], "\n")
+	}
+
+	return commitMessage
+}
+
+// isVerboseCommitMessage tells if the message holds the diff git adds below the scissors line for
+// 'git commit --verbose'. The diff follows the comment lines below the scissors line.
+func isVerboseCommitMessage(commitMessage string, commentChar string) bool {
+	lines := strings.Split(commitMessage, "\n")
+	i := findScissorsLine(lines, commentChar)
+	if i < 0 {
+		return false
+	}
+
+	for _, line := range lines[i+1:] {
+		if strings.HasPrefix(line, commentChar) {
+			continue
+		}
+		return strings.HasPrefix(line, "diff --git ")
+	}
+
+	return false
+}
+
+func findScissorsLine(lines []string, commentChar string) int {
+	for i, line := range lines {
+		if strings.TrimRight(line, "\r") == commentChar+" "+scissorsLine {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// stripSpace removes trailing whitespace of every line, collapses multiple empty lines and removes leading and
+// trailing empty lines. If commentChar is not empty, lines starting with commentChar are removed.
+func stripSpace(commitMessage string, commentChar string) string {
+	var cleanedLines []string
+	emptyLines := 0
+	for _, line := range strings.Split(commitMessage, "\n") {
+		if commentChar != "" && strings.HasPrefix(line, commentChar) {
+			continue
+		}
+
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			emptyLines++
+			continue
+		}
+
+		if emptyLines > 0 && len(cleanedLines) > 0 {
+			cleanedLines = append(cleanedLines, "")
+		}
+		emptyLines = 0
+		cleanedLines = append(cleanedLines, line)
+	}
+
+	return strings.Join(cleanedLines, "\n")
+}
@@ -0,0 +1,135 @@
+package hook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var cleanupOriginals = struct {
+	getGitConfigValueFunc getGitConfigValueFuncDef
+	getEnvFunc            getEnvFuncDef
+}{
+	getGitConfigValueFunc: getGitConfigValueFunc,
+	getEnvFunc:            getEnvFunc,
+}
+
+func restoreCleanupOriginals() {
+	getGitConfigValueFunc = cleanupOriginals.getGitConfigValueFunc
+	getEnvFunc = cleanupOriginals.getEnvFunc
+}
+
+const commentedScissorsLine = "# " + scissorsLine
+
+const verboseCommitMessage = `
+
+fix the login   
+#123 is not a comment for ';'
+
+
+see PROJECT-1
+# Please enter the commit message for your changes. Lines starting
+# with '#' will be ignored.
+# ------------------------ >8 ------------------------
+# Do not modify or remove the line above.
+diff --git a/README.md b/README.md
++PROJECT-2
+`
+
+func TestCleanupCommitMessage(t *testing.T) {
+	testDataSet := map[string]struct {
+		commitMessage   string
+		cleanupMode     CleanupMode
+		commentChar     string
+		expectedMessage string
+	}{
+		"strip": {
+			cleanupMode:     CleanupModeStrip,
+			commentChar:     "#",
+			expectedMessage: "fix the login\n\nsee PROJECT-1",
+		},
+		"strip without diff below scissors line": {
+			commitMessage:   "fix the login\n\n" + commentedScissorsLine + "\nsee PROJECT-1\n",
+			cleanupMode:     CleanupModeStrip,
+			commentChar:     "#",
+			expectedMessage: "fix the login\n\nsee PROJECT-1",
+		},
+		"strip with custom comment char": {
+			cleanupMode:     CleanupModeStrip,
+			commentChar:     ";",
+			expectedMessage: "fix the login\n#123 is not a comment for ';'\n\nsee PROJECT-1\n# Please enter the commit message for your changes. Lines starting\n# with '#' will be ignored.\n# ------------------------ >8 ------------------------\n# Do not modify or remove the line above.\ndiff --git a/README.md b/README.md\n+PROJECT-2",
+		},
+		"scissors": {
+			cleanupMode:     CleanupModeScissors,
+			commentChar:     "#",
+			expectedMessage: "fix the login\n#123 is not a comment for ';'\n\nsee PROJECT-1\n# Please enter the commit message for your changes. Lines starting\n# with '#' will be ignored.",
+		},
+		"whitespace": {
+			cleanupMode:     CleanupModeWhitespace,
+			commentChar:     "#",
+			expectedMessage: "fix the login\n#123 is not a comment for ';'\n\nsee PROJECT-1\n# Please enter the commit message for your changes. Lines starting\n# with '#' will be ignored.\n# ------------------------ >8 ------------------------\n# Do not modify or remove the line above.\ndiff --git a/README.md b/README.md\n+PROJECT-2",
+		},
+		"verbatim": {
+			cleanupMode:     CleanupModeVerbatim,
+			commentChar:     "#",
+			expectedMessage: verboseCommitMessage,
+		},
+	}
+
+	for testCaseName, testData := range testDataSet {
+		t.Run(testCaseName, func(t *testing.T) {
+			commitMessage := testData.commitMessage
+			if commitMessage == "" {
+				commitMessage = verboseCommitMessage
+			}
+
+			cleanedMessage := cleanupCommitMessage(commitMessage, testData.cleanupMode, testData.commentChar)
+
+			assert.Exactly(t, testData.expectedMessage, cleanedMessage)
+		})
+	}
+}
+
+func TestCleanupCommitMessageByGitSettings(t *testing.T) {
+	defer restoreCleanupOriginals()
+
+	testDataSet := map[string]struct {
+		gitConfig       map[string]string
+		gitEditor       string
+		expectedMessage string
+	}{
+		"default mode with editor strips commentary": {
+			gitConfig:       map[string]string{},
+			expectedMessage: "fix\n\nsee PROJECT-1",
+		},
+		"default mode without editor only cleans whitespace": {
+			gitConfig:       map[string]string{"commit.cleanup": "default"},
+			gitEditor:       ":",
+			expectedMessage: "fix\n#1 ;2\n\nsee PROJECT-1",
+		},
+		"configured comment char": {
+			gitConfig:       map[string]string{"core.commentChar": ";"},
+			expectedMessage: "fix\n#1 ;2\n\nsee PROJECT-1",
+		},
+		"configured cleanup mode": {
+			gitConfig:       map[string]string{"commit.cleanup": "verbatim"},
+			expectedMessage: "fix\n#1 ;2\n\nsee PROJECT-1\n",
+		},
+	}
+
+	for testCaseName, testData := range testDataSet {
+		t.Run(testCaseName, func(t *testing.T) {
+			getGitConfigValueFunc = func(key string) string { return testData.gitConfig[key] }
+			getEnvFunc = func(key string) string {
+				if key == "GIT_EDITOR" {
+					return testData.gitEditor
+				}
+				return ""
+			}
+
+			cleanedMessage := cleanupCommitMessageByGitSettings("fix\n#1 ;2\n\nsee PROJECT-1\n")
+
+			assert.Exactly(t, testData.expectedMessage, cleanedMessage)
+		})
+	}
+}
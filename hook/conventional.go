@@ -0,0 +1,171 @@
+package hook
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/Oppodelldog/git-commit-hook/config"
+)
+
+type (
+	// ConventionalCommit holds the parts of a commit message that follows the conventional commits specification
+	ConventionalCommit struct {
+		Type        string
+		Scope       string
+		Breaking    bool
+		Description string
+		Body        string
+		Footers     []ConventionalCommitFooter
+	}
+
+	// ConventionalCommitFooter is a footer of a conventional commit, like 'BREAKING CHANGE: removed xyz'
+	ConventionalCommitFooter struct {
+		Token string
+		Value string
+	}
+)
+
+var conventionalFooterPattern = regexp.MustCompile(`^(BREAKING CHANGE|[A-Za-z0-9-]+)(: | #)(.*)$`)
+
+// ParseConventionalCommit parses the given commit message into its conventional commit parts.
+// Every part that violates the specification is reported in the returned list of errors.
+func ParseConventionalCommit(commitMessage string) (ConventionalCommit, []string) {
+	var parsed ConventionalCommit
+	var errs []string
+
+	lines := strings.Split(strings.Replace(commitMessage, "\r\n", "\n", -1), "\n")
+
+	errs = append(errs, parseConventionalHeader(lines[0], &parsed)...)
+
+	if len(lines) > 1 {
+		if strings.TrimSpace(lines[1]) != "" {
+			errs = append(errs, "body must be separated from the header by a blank line")
+		}
+		parsed.Body, parsed.Footers = splitConventionalBodyAndFooters(lines[1:])
+	}
+
+	for _, footer := range parsed.Footers {
+		if isBreakingChangeToken(footer.Token) {
+			parsed.Breaking = true
+			if strings.TrimSpace(footer.Value) == "" {
+				errs = append(errs, fmt.Sprintf("footer '%s' must have a description", footer.Token))
+			}
+		}
+	}
+
+	return parsed, errs
+}
+
+func parseConventionalHeader(header string, parsed *ConventionalCommit) []string {
+	rest := header
+
+	typeEnd := strings.IndexFunc(rest, func(r rune) bool { return !unicode.IsLetter(r) })
+	if typeEnd == -1 {
+		typeEnd = len(rest)
+	}
+	parsed.Type = rest[:typeEnd]
+	rest = rest[typeEnd:]
+	if parsed.Type == "" {
+		return []string{fmt.Sprintf("type is missing in header '%s'", header)}
+	}
+
+	if strings.HasPrefix(rest, "(") {
+		scopeEnd := strings.Index(rest, ")")
+		if scopeEnd == -1 {
+			return []string{fmt.Sprintf("scope is not closed by ')' in header '%s'", header)}
+		}
+		parsed.Scope = rest[1:scopeEnd]
+		rest = rest[scopeEnd+1:]
+		if strings.TrimSpace(parsed.Scope) == "" {
+			return []string{"scope must not be empty, omit the parentheses if there is no scope"}
+		}
+	}
+
+	if strings.HasPrefix(rest, "!") {
+		parsed.Breaking = true
+		rest = rest[1:]
+	}
+
+	if !strings.HasPrefix(rest, ": ") {
+		return []string{fmt.Sprintf("expected ': ' after type, scope or '!' in header '%s'", header)}
+	}
+
+	parsed.Description = strings.TrimSpace(rest[2:])
+	if parsed.Description == "" {
+		return []string{"description is missing after ': '"}
+	}
+
+	return nil
+}
+
+// splitConventionalBodyAndFooters separates the footers from the body. Footers are the last paragraph, if its
+// first line is a footer. Lines that do not start a new footer continue the value of the previous footer.
+func splitConventionalBodyAndFooters(lines []string) (string, []ConventionalCommitFooter) {
+	lastParagraphStart := 0
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lastParagraphStart = i + 1
+		}
+	}
+
+	if lastParagraphStart >= len(lines) || !conventionalFooterPattern.MatchString(lines[lastParagraphStart]) {
+		return strings.TrimSpace(strings.Join(lines, "\n")), nil
+	}
+
+	var footers []ConventionalCommitFooter
+	for _, line := range lines[lastParagraphStart:] {
+		matches := conventionalFooterPattern.FindStringSubmatch(line)
+		if matches == nil {
+			footers[len(footers)-1].Value += "\n" + line
+			continue
+		}
+		footers = append(footers, ConventionalCommitFooter{Token: matches[1], Value: matches[3]})
+	}
+
+	return strings.TrimSpace(strings.Join(lines[:lastParagraphStart], "\n")), footers
+}
+
+func isBreakingChangeToken(token string) bool {
+	return token == "BREAKING CHANGE" || token == "BREAKING-CHANGE"
+}
+
+// validateConventionalCommit validates the given commit message against the conventional commits specification
+// and the allowed types and scopes of the given configuration.
+func validateConventionalCommit(conventionalConfig config.ConventionalCommitConfiguration, commitMessage string) []string {
+	parsed, errs := ParseConventionalCommit(commitMessage)
+	if parsed.Type == "" {
+		return errs
+	}
+
+	allowedTypes := conventionalConfig.AllowedTypes()
+	if !containsFold(allowedTypes, parsed.Type) {
+		errs = append(errs, fmt.Sprintf("type '%s' is not allowed, allowed types: %s", parsed.Type, strings.Join(allowedTypes, ", ")))
+	}
+
+	// the scope can only be checked if the header was parsed completely
+	if parsed.Description == "" {
+		return errs
+	}
+
+	if parsed.Scope == "" {
+		if conventionalConfig.RequireScope {
+			errs = append(errs, "scope is missing, e.g. 'feat(scope): description'")
+		}
+	} else if len(conventionalConfig.Scopes) > 0 && !containsFold(conventionalConfig.Scopes, parsed.Scope) {
+		errs = append(errs, fmt.Sprintf("scope '%s' is not allowed, allowed scopes: %s", parsed.Scope, strings.Join(conventionalConfig.Scopes, ", ")))
+	}
+
+	return errs
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+
+	return false
+}
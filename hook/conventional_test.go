@@ -0,0 +1,102 @@
+package hook
+
+import (
+	"testing"
+
+	"github.com/Oppodelldog/git-commit-hook/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConventionalCommit(t *testing.T) {
+	commitMessage := "feat(api)!: send an email to the customer\n\n" +
+		"The email is sent when a product is shipped.\n\n" +
+		"Refs #133\n" +
+		"BREAKING CHANGE: the shipping endpoint\n" +
+		"  returns a new status code"
+
+	parsed, errs := ParseConventionalCommit(commitMessage)
+
+	assert.Empty(t, errs)
+	assert.Exactly(t, ConventionalCommit{
+		Type:        "feat",
+		Scope:       "api",
+		Breaking:    true,
+		Description: "send an email to the customer",
+		Body:        "The email is sent when a product is shipped.",
+		Footers: []ConventionalCommitFooter{
+			{Token: "Refs", Value: "133"},
+			{Token: "BREAKING CHANGE", Value: "the shipping endpoint\n  returns a new status code"},
+		},
+	}, parsed)
+}
+
+func TestParseConventionalCommit_BreakingChangeFooter(t *testing.T) {
+	parsed, errs := ParseConventionalCommit("fix: typo\n\nBREAKING-CHANGE: renamed flag")
+
+	assert.Empty(t, errs)
+	assert.True(t, parsed.Breaking)
+	assert.Exactly(t, "", parsed.Body)
+}
+
+func TestValidateConventionalCommit(t *testing.T) {
+	testDataSet := map[string]struct {
+		config         config.ConventionalCommitConfiguration
+		commitMessage  string
+		expectedErrors []string
+	}{
+		"valid minimal": {
+			commitMessage: "docs: correct spelling",
+		},
+		"type missing": {
+			commitMessage:  "(api): something",
+			expectedErrors: []string{"type is missing in header '(api): something'"},
+		},
+		"type not allowed": {
+			config:         config.ConventionalCommitConfiguration{Types: []string{"feat", "fix"}},
+			commitMessage:  "docs: something",
+			expectedErrors: []string{"type 'docs' is not allowed, allowed types: feat, fix"},
+		},
+		"scope not closed": {
+			commitMessage:  "feat(api: something",
+			expectedErrors: []string{"scope is not closed by ')' in header 'feat(api: something'"},
+		},
+		"scope empty": {
+			commitMessage:  "feat(): something",
+			expectedErrors: []string{"scope must not be empty, omit the parentheses if there is no scope"},
+		},
+		"scope missing": {
+			config:         config.ConventionalCommitConfiguration{RequireScope: true},
+			commitMessage:  "feat: something",
+			expectedErrors: []string{"scope is missing, e.g. 'feat(scope): description'"},
+		},
+		"scope not allowed": {
+			config:         config.ConventionalCommitConfiguration{Scopes: []string{"api", "ui"}},
+			commitMessage:  "feat(db): something",
+			expectedErrors: []string{"scope 'db' is not allowed, allowed scopes: api, ui"},
+		},
+		"separator missing": {
+			commitMessage:  "feat something",
+			expectedErrors: []string{"expected ': ' after type, scope or '!' in header 'feat something'"},
+		},
+		"description missing": {
+			commitMessage:  "feat(api)!: ",
+			expectedErrors: []string{"description is missing after ': '"},
+		},
+		"body not separated": {
+			commitMessage:  "fix: something\nbody",
+			expectedErrors: []string{"body must be separated from the header by a blank line"},
+		},
+		"breaking change footer without description": {
+			commitMessage:  "fix: something\n\nBREAKING CHANGE: ",
+			expectedErrors: []string{"footer 'BREAKING CHANGE' must have a description"},
+		},
+	}
+
+	for testCaseName, testData := range testDataSet {
+		t.Run(testCaseName, func(t *testing.T) {
+			errs := validateConventionalCommit(testData.config, testData.commitMessage)
+
+			assert.Exactly(t, testData.expectedErrors, errs)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package hook
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Oppodelldog/git-commit-hook/git"
+)
+
+// MessageKind defines the kind of a commit message like merge or fixup
+type MessageKind string
+
+const (
+	// MessageKindRegular is a commit message written by the user
+	MessageKindRegular MessageKind = ""
+	// MessageKindMerge is the message of a merge commit
+	MessageKindMerge MessageKind = "merge"
+	// MessageKindSquash is a message created by 'git commit --squash'
+	MessageKindSquash MessageKind = "squash"
+	// MessageKindFixup is a message created by 'git commit --fixup'
+	MessageKindFixup MessageKind = "fixup"
+	// MessageKindRevert is the default message created by 'git revert'
+	MessageKindRevert MessageKind = "revert"
+)
+
+var mergeMessagePattern = regexp.MustCompile(`^Merge (branch|branches|remote-tracking branch|tag|commit|pull request) `)
+
+type isMergeInProgressFuncDef func() bool
+
+var isMergeInProgressFunc = isMergeInProgressFuncDef(git.IsMergeInProgress)
+
+// detectMessageKind detects the kind of the given commit message by its content and the state of the repository
+func detectMessageKind(commitMessage string) MessageKind {
+	trimmedCommitMessage := strings.TrimLeft(commitMessage, " \t\r\n")
+
+	switch {
+	case strings.HasPrefix(trimmedCommitMessage, "fixup! "), strings.HasPrefix(trimmedCommitMessage, "amend! "):
+		return MessageKindFixup
+	case strings.HasPrefix(trimmedCommitMessage, "squash! "):
+		return MessageKindSquash
+	case strings.HasPrefix(trimmedCommitMessage, `Revert "`):
+		return MessageKindRevert
+	case mergeMessagePattern.MatchString(trimmedCommitMessage), isMergeInProgressFunc():
+		return MessageKindMerge
+	}
+
+	return MessageKindRegular
+}
@@ -0,0 +1,40 @@
+package hook
+
+import (
+	"testing"
+
+	"github.com/Oppodelldog/git-commit-hook/git"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectMessageKind(t *testing.T) {
+	defer func() { isMergeInProgressFunc = git.IsMergeInProgress }()
+	isMergeInProgressFunc = func() bool { return false }
+
+	testDataSet := map[string]struct {
+		commitMessage string
+		expectedKind  MessageKind
+	}{
+		"regular":             {commitMessage: "fixed the fixup! handling", expectedKind: MessageKindRegular},
+		"fixup":               {commitMessage: "fixup! initial commit", expectedKind: MessageKindFixup},
+		"amend":               {commitMessage: "amend! initial commit\n\nnew message", expectedKind: MessageKindFixup},
+		"squash":              {commitMessage: "squash! initial commit", expectedKind: MessageKindSquash},
+		"revert":              {commitMessage: "Revert \"initial commit\"\n\nThis reverts commit abc.", expectedKind: MessageKindRevert},
+		"merge branch":        {commitMessage: "Merge branch 'feature/x' into develop", expectedKind: MessageKindMerge},
+		"merge remote branch": {commitMessage: "Merge remote-tracking branch 'origin/develop'", expectedKind: MessageKindMerge},
+		"merge pull request":  {commitMessage: "Merge pull request #1 from acme/feature", expectedKind: MessageKindMerge},
+	}
+
+	for testCaseName, testData := range testDataSet {
+		t.Run(testCaseName, func(t *testing.T) {
+			assert.Exactly(t, testData.expectedKind, detectMessageKind(testData.commitMessage))
+		})
+	}
+}
+
+func TestDetectMessageKind_MergeInProgress(t *testing.T) {
+	defer func() { isMergeInProgressFunc = git.IsMergeInProgress }()
+	isMergeInProgressFunc = func() bool { return true }
+
+	assert.Exactly(t, MessageKindMerge, detectMessageKind("some custom merge message"))
+}
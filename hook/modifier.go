@@ -12,48 +12,95 @@ type (
 	}
 	commitMessageModifier struct {
 		createViewModelFunc       createViewModelFuncDef
+		resolveBranchTypeFunc     resolveBranchTypeFuncDef
 		renderCommitMessageFunc   renderCommitMessageFuncDef
+		writeTrailersFunc         writeTrailersFuncDef
 		validateCommitmessageFunc validateCommitMessageFuncDef
+		detectMessageKindFunc     detectMessageKindFuncDef
+		getMessageKindActionFunc  getMessageKindActionFuncDef
+		cleanupCommitMessageFunc  cleanupCommitMessageFuncDef
+		noBranchAction            config.NoBranchAction
 	}
 
 	createViewModelFuncDef       func(gitCommitMessage string, branchName string) ViewModel
-	validateCommitMessageFuncDef func(branchName, modifiedCommitMessage string) error
+	resolveBranchTypeFuncDef     func(viewModel ViewModel) (ViewModel, error)
+	validateCommitMessageFuncDef func(viewModel ViewModel, modifiedCommitMessage string) error
 	renderCommitMessageFuncDef   func(viewModel ViewModel) (string, error)
+	writeTrailersFuncDef         func(viewModel ViewModel, commitMessage string) (string, error)
+	detectMessageKindFuncDef     func(gitCommitMessage string) MessageKind
+	getMessageKindActionFuncDef  func(messageKind string) config.MessageKindAction
+	cleanupCommitMessageFuncDef  func(gitCommitMessage string) string
 )
 
 // NewCommitMessageModifier create a CommitMessageModifier
 func NewCommitMessageModifier(projectConfiguration config.Project) CommitMessageModifier {
 	return &commitMessageModifier{
 		createViewModelFunc:       createViewModel,
+		resolveBranchTypeFunc:     newBranchTypeResolver(projectConfiguration),
 		renderCommitMessageFunc:   NewCommitMessageRenderer(projectConfiguration).Render,
-		validateCommitmessageFunc: NewCommitMessageValidator(projectConfiguration).Validate,
+		writeTrailersFunc:         NewCommitMessageTrailerWriter(projectConfiguration).WriteTrailers,
+		validateCommitmessageFunc: newCommitMessageValidator(projectConfiguration).validate,
+		detectMessageKindFunc:     detectMessageKind,
+		getMessageKindActionFunc:  projectConfiguration.GetMessageKindAction,
+		cleanupCommitMessageFunc:  cleanupCommitMessageByGitSettings,
+		noBranchAction:            projectConfiguration.GetNoBranchAction(),
 	}
 }
 
 //ModifyGitCommitMessage prepends the current branch name to the given git commit message.
 // if the current branch name is detected to be NO feature branch, the user will be prompted to enter
 // a feature branch manually. This is then inserted in between current branch and commit message.
-// If no valid branch name could be determined the function returns an error
+// If no branch name could be determined, the configured no branch action decides if the message is
+// left untouched, modified using the fallback branch type or rejected.
+// The configured trailers are added to the rendered message, duplicate trailers are removed.
+// Commentary and the scissors section are removed the way git does before the message is modified.
+// Depending on the configuration, merge, squash, fixup or revert messages are skipped, only rendered or only validated.
 func (m *commitMessageModifier) ModifyGitCommitMessage(gitCommitMessage string, branchName string) (modifiedCommitMessage string, err error) {
 
 	modifiedCommitMessage = gitCommitMessage
-	if gitCommitMessage == "" {
+	cleanedCommitMessage := m.cleanupCommitMessageFunc(gitCommitMessage)
+	if cleanedCommitMessage == "" {
 		err = errors.New("commit message is empty")
 		return
 	}
 
-	if branchName == "" {
+	if branchName == "" && m.noBranchAction != config.NoBranchActionFallback {
+		if m.noBranchAction == config.NoBranchActionFail {
+			modifiedCommitMessage = ""
+			err = errors.New("could not determine the current branch")
+		}
 		return
 	}
 
-	viewModel := createViewModel(gitCommitMessage, branchName)
+	messageKindAction := m.getMessageKindActionFunc(string(m.detectMessageKindFunc(cleanedCommitMessage)))
+	if messageKindAction == config.MessageKindActionSkip {
+		return
+	}
 
-	modifiedCommitMessage, err = m.renderCommitMessageFunc(viewModel)
+	viewModel, err := m.resolveBranchTypeFunc(m.createViewModelFunc(cleanedCommitMessage, branchName))
 	if err != nil {
+		modifiedCommitMessage = ""
+		return
+	}
+
+	if messageKindAction == config.MessageKindActionValidate {
+		modifiedCommitMessage = viewModel.CommitMessage
+	} else {
+		modifiedCommitMessage, err = m.renderCommitMessageFunc(viewModel)
+		if err != nil {
+			return
+		}
+		modifiedCommitMessage, err = m.writeTrailersFunc(viewModel, modifiedCommitMessage)
+		if err != nil {
+			return
+		}
+	}
+
+	if messageKindAction == config.MessageKindActionTemplate {
 		return
 	}
 
-	err = m.validateCommitmessageFunc(branchName, modifiedCommitMessage)
+	err = m.validateCommitmessageFunc(viewModel, modifiedCommitMessage)
 	if err != nil {
 		modifiedCommitMessage = ""
 	}
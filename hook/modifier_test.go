@@ -6,6 +6,7 @@ import (
 	"errors"
 
 	"github.com/Oppodelldog/git-commit-hook/config"
+	"github.com/Oppodelldog/git-commit-hook/git"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -38,6 +39,11 @@ func TestModifyGitCommitMessage(t *testing.T) {
 			branchName: "release/v1.0.1-fix",
 			output:     "release/v1.0.1-fix: fixed something for PROJECT-123, should work now",
 		},
+		"amend of an already modified commit message": {
+			input:      "PROJECT-123: initial commit\n",
+			branchName: "PROJECT-123",
+			output:     "PROJECT-123: initial commit",
+		},
 		"commit for feature without commit message": {
 			input:         "",
 			branchName:    "PROJECT-123",
@@ -59,9 +65,9 @@ func TestModifyGitCommitMessage(t *testing.T) {
 	}
 
 	prjCfg := config.Project{
-		BranchTypes: map[string]config.BranchTypePattern{
-			"feature": `(?m)^((origin\/)*feature\/.*)|(?:\s|^|/)(([A-Z](_)*)+-[0-9]+)([\s,;:!.-]|$)`,
-			"release": `(?m)^(origin\/)*release\/v([0-9]*\.*)*(-fix)*$`,
+		BranchTypes: config.BranchTypes{
+			{Name: "feature", Pattern: `(?m)^((origin\/)*feature\/.*)|(?:\s|^|/)(([A-Z](_)*)+-[0-9]+)([\s,;:!.-]|$)`},
+			{Name: "release", Pattern: `(?m)^(origin\/)*release\/v([0-9]*\.*)*(-fix)*$`},
 		},
 		Templates: map[string]config.BranchTypeTemplate{
 			"feature": "{{.BranchName}}: {{.CommitMessage}}",
@@ -103,3 +109,208 @@ func TestModifyGitCommitMessage_commitMessageRendererReturnsError_ExpectError(t
 
 	assert.Exactly(t, errStub, err)
 }
+
+func TestModifyGitCommitMessage_branchTypeResolverReturnsError_ExpectError(t *testing.T) {
+
+	commitMessage := "some message"
+	branchName := "feature123"
+	errStub := errors.New("stubbed branch type resolver error")
+	modifier := NewCommitMessageModifier(config.Project{})
+	modifier.(*commitMessageModifier).resolveBranchTypeFunc = func(viewModel ViewModel) (ViewModel, error) {
+		return viewModel, errStub
+	}
+	modifiedCommitMessage, err := modifier.ModifyGitCommitMessage(commitMessage, branchName)
+
+	assert.Exactly(t, errStub, err)
+	assert.Empty(t, modifiedCommitMessage)
+}
+
+func TestModifyGitCommitMessage_ValidatesByTheResolvedBranchType(t *testing.T) {
+	defer restoreViewModelOriginals()
+	withoutRepositoryState()
+	modifier := NewCommitMessageModifier(config.Project{})
+	modifier.(*commitMessageModifier).resolveBranchTypeFunc = func(viewModel ViewModel) (ViewModel, error) {
+		viewModel.BranchType = "feature"
+		return viewModel, nil
+	}
+	var validatedViewModel ViewModel
+	modifier.(*commitMessageModifier).validateCommitmessageFunc = func(viewModel ViewModel, modifiedCommitMessage string) error {
+		validatedViewModel = viewModel
+		return nil
+	}
+
+	_, err := modifier.ModifyGitCommitMessage("some message", "feature123")
+
+	assert.NoError(t, err)
+	assert.Exactly(t, "feature", validatedViewModel.BranchType)
+}
+
+func TestModifyGitCommitMessage_MessageKindActions(t *testing.T) {
+	prjCfg := config.Project{
+		Templates: map[string]config.BranchTypeTemplate{
+			"*": "{{.BranchName}}: {{.CommitMessage}}",
+		},
+		Validation: map[string]config.BranchValidationConfiguration{
+			"*": {"PROJECT-[0-9]+": "valid ticket ID"},
+		},
+		MessageKinds: map[string]config.MessageKindAction{
+			"squash": config.MessageKindActionTemplate,
+			"revert": config.MessageKindActionValidate,
+		},
+	}
+
+	testCases := map[string]struct {
+		input         string
+		output        string
+		errorContains string
+	}{
+		"fixup is skipped by default": {
+			input:  "fixup! initial commit\n",
+			output: "fixup! initial commit\n",
+		},
+		"squash is only rendered": {
+			input:  "squash! initial commit",
+			output: "PROJECT-1: squash! initial commit",
+		},
+		"revert is only validated": {
+			input:         "Revert \"initial commit\"",
+			errorContains: "validation error for branch 'PROJECT-1'",
+		},
+		"regular message is rendered and validated": {
+			input:  "fixed PROJECT-1",
+			output: "PROJECT-1: fixed PROJECT-1",
+		},
+	}
+
+	for testName, testData := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			modifier := NewCommitMessageModifier(prjCfg)
+			modifier.(*commitMessageModifier).detectMessageKindFunc = func(gitCommitMessage string) MessageKind {
+				defer func() { isMergeInProgressFunc = git.IsMergeInProgress }()
+				isMergeInProgressFunc = func() bool { return false }
+				return detectMessageKind(gitCommitMessage)
+			}
+			modifiedGitCommitMessage, err := modifier.ModifyGitCommitMessage(testData.input, "PROJECT-1")
+
+			if testData.errorContains != "" {
+				assert.Contains(t, err.Error(), testData.errorContains)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Exactly(t, testData.output, modifiedGitCommitMessage)
+		})
+	}
+}
+
+func TestModifyGitCommitMessage_NoBranchActions(t *testing.T) {
+	testCases := map[string]struct {
+		noBranch      config.NoBranchConfiguration
+		output        string
+		errorContains string
+	}{
+		"skip by default": {
+			output: "initial commit",
+		},
+		"fail": {
+			noBranch:      config.NoBranchConfiguration{Action: config.NoBranchActionFail},
+			errorContains: "could not determine the current branch",
+		},
+		"fallback branch type": {
+			noBranch: config.NoBranchConfiguration{Action: config.NoBranchActionFallback, BranchType: "detached"},
+			output:   "[detached] initial commit",
+		},
+	}
+
+	for testName, testData := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			prjCfg := config.Project{
+				Templates: map[string]config.BranchTypeTemplate{
+					"detached": "[detached] {{.CommitMessage}}",
+				},
+				NoBranch: testData.noBranch,
+			}
+			modifier := NewCommitMessageModifier(prjCfg)
+
+			modifiedGitCommitMessage, err := modifier.ModifyGitCommitMessage("initial commit", "")
+
+			if testData.errorContains != "" {
+				assert.Contains(t, err.Error(), testData.errorContains)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Exactly(t, testData.output, modifiedGitCommitMessage)
+		})
+	}
+}
+
+func TestModifyGitCommitMessage_FallbackTemplateOnDetachedHead(t *testing.T) {
+	defer restoreViewModelOriginals()
+	withoutRepositoryState()
+	getHeadFunc = func() (git.Head, error) {
+		return git.Head{Detached: true, CommitSHA: "0123abcdef0123abcdef0123abcdef0123abcdef"}, nil
+	}
+	prjCfg := config.Project{
+		Templates: map[string]config.BranchTypeTemplate{
+			"detached": "{{printf \"%.7s\" .CommitSHA}}: {{.CommitMessage}}",
+		},
+		NoBranch: config.NoBranchConfiguration{Action: config.NoBranchActionFallback, BranchType: "detached"},
+	}
+	modifier := NewCommitMessageModifier(prjCfg)
+
+	modifiedGitCommitMessage, err := modifier.ModifyGitCommitMessage("initial commit", "")
+
+	assert.NoError(t, err)
+	assert.Exactly(t, "0123abc: initial commit", modifiedGitCommitMessage)
+}
+
+func TestModifyGitCommitMessage_Trailers(t *testing.T) {
+	defer restoreViewModelOriginals()
+	withoutRepositoryState()
+	prjCfg := config.Project{
+		BranchTypes: config.BranchTypes{
+			{Name: "feature", Pattern: `^feature/(?P<ticket>[A-Z]+-[0-9]+)$`},
+		},
+		Templates: map[string]config.BranchTypeTemplate{
+			"feature": "{{.Branch.ticket}}: {{.CommitMessage}}",
+		},
+		Trailers: map[string]config.TrailerConfiguration{
+			"feature": {
+				Add:     []config.TrailerTemplate{{Key: "Ticket", Value: "{{.Branch.ticket}}"}},
+				Require: []string{"Ticket", "Reviewed-by"},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		input         string
+		output        string
+		errorContains string
+	}{
+		"trailers are added": {
+			input:  "initial commit\n\nReviewed-by: Joe",
+			output: "PROJECT-1: initial commit\n\nReviewed-by: Joe\nTicket: PROJECT-1",
+		},
+		"amend keeps trailers": {
+			input:  "PROJECT-1: initial commit\n\nReviewed-by: Joe\nTicket: PROJECT-1\n",
+			output: "PROJECT-1: initial commit\n\nReviewed-by: Joe\nTicket: PROJECT-1",
+		},
+		"required trailer is missing": {
+			input:         "initial commit",
+			errorContains: "the following trailers are missing\n - Reviewed-by\n",
+		},
+	}
+
+	for testName, testData := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			modifier := NewCommitMessageModifier(prjCfg)
+			modifiedGitCommitMessage, err := modifier.ModifyGitCommitMessage(testData.input, "feature/PROJECT-1")
+
+			if testData.errorContains != "" {
+				assert.Contains(t, err.Error(), testData.errorContains)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Exactly(t, testData.output, modifiedGitCommitMessage)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package hook
+
+import (
+	"github.com/Oppodelldog/git-commit-hook/config"
+	"github.com/pkg/errors"
+)
+
+const (
+	// CommitMessageHookName is the name of the git hook that validates and modifies the commit message
+	CommitMessageHookName = "commit-msg"
+	// PrepareCommitMessageHookName is the name of the git hook that prefills the commit message before the editor opens
+	PrepareCommitMessageHookName = "prepare-commit-msg"
+)
+
+type (
+	// CommitMessagePreparer implements prefilling a commit message before the editor opens
+	CommitMessagePreparer interface {
+		PrepareGitCommitMessage(gitCommitMessage, branchName, source string) (preparedCommitMessage string, err error)
+	}
+
+	commitMessagePreparer struct {
+		resolveBranchTypeFunc   resolveBranchTypeFuncDef
+		renderCommitMessageFunc renderCommitMessageFuncDef
+		noBranchAction          config.NoBranchAction
+	}
+)
+
+// NewCommitMessagePreparer creates a CommitMessagePreparer which renders the prepare templates of the given project
+func NewCommitMessagePreparer(projectConfiguration config.Project) CommitMessagePreparer {
+	prepareConfiguration := projectConfiguration
+	prepareConfiguration.Templates = projectConfiguration.PrepareTemplates
+
+	return &commitMessagePreparer{
+		resolveBranchTypeFunc:   newBranchTypeResolver(projectConfiguration),
+		renderCommitMessageFunc: NewCommitMessageRenderer(prepareConfiguration).Render,
+		noBranchAction:          projectConfiguration.GetNoBranchAction(),
+	}
+}
+
+// PrepareGitCommitMessage inserts the rendered prepare template at the top of the given commit message.
+// Git passes the source of the commit message to the hook, the message is only prepared if it was not given
+// by -m, -F, -c, -C, --amend, merge or squash.
+func (p *commitMessagePreparer) PrepareGitCommitMessage(gitCommitMessage, branchName, source string) (preparedCommitMessage string, err error) {
+	preparedCommitMessage = gitCommitMessage
+	if branchName == "" && p.noBranchAction != config.NoBranchActionFallback {
+		return
+	}
+
+	if source != "" && source != "template" {
+		return
+	}
+
+	viewModel, err := p.resolveBranchTypeFunc(createViewModel("", branchName))
+	if err != nil {
+		return
+	}
+
+	skeleton, err := p.renderCommitMessageFunc(viewModel)
+	if err != nil {
+		return
+	}
+
+	preparedCommitMessage = skeleton + gitCommitMessage
+
+	return
+}
+
+//PrepareCommitMessage prefills the commit message in the given commit message file
+func PrepareCommitMessage(commitMessageFile, source string, commitMessagePreparer CommitMessagePreparer) error {
+	fileContent, err := readFileFunc(commitMessageFile)
+	if err != nil {
+		return errors.Errorf("error reading commit message from '%s': %v", commitMessageFile, err.Error())
+	}
+
+	outputMessage, err := commitMessagePreparer.PrepareGitCommitMessage(string(fileContent), getBranchName(), source)
+	if err != nil {
+		return errors.Errorf("error preparing commit message: %s", err.Error())
+	}
+
+	err = writeFileFunc(commitMessageFile, []byte(outputMessage), 0777)
+	if err != nil {
+		return errors.Errorf("error writing commit message to '%s': %s", commitMessageFile, err.Error())
+	}
+
+	return nil
+}
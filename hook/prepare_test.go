@@ -0,0 +1,102 @@
+package hook
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Oppodelldog/git-commit-hook/config"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrepareGitCommitMessage(t *testing.T) {
+	prjCfg := config.Project{
+		BranchTypes: config.BranchTypes{
+			{Name: "feature", Pattern: `^feature/(?P<ticket>[A-Z]+-[0-9]+)`},
+		},
+		PrepareTemplates: map[string]config.BranchTypeTemplate{
+			"feature": "{{.Branch.ticket}} <type>: ",
+		},
+	}
+	editorMessage := "\n# Please enter the commit message for your changes.\n"
+
+	testCases := map[string]struct {
+		branchName string
+		source     string
+		output     string
+	}{
+		"editor is prefilled": {
+			branchName: "feature/PROJECT-1-login",
+			output:     "PROJECT-1 <type>: " + editorMessage,
+		},
+		"commit template is prefilled": {
+			branchName: "feature/PROJECT-1-login",
+			source:     "template",
+			output:     "PROJECT-1 <type>: " + editorMessage,
+		},
+		"message given by -m is not prefilled": {
+			branchName: "feature/PROJECT-1-login",
+			source:     "message",
+			output:     editorMessage,
+		},
+		"amend is not prefilled": {
+			branchName: "feature/PROJECT-1-login",
+			source:     "commit",
+			output:     editorMessage,
+		},
+		"branch type without prepare template": {
+			branchName: "develop",
+			output:     editorMessage,
+		},
+		"unknown branch": {
+			branchName: "",
+			output:     editorMessage,
+		},
+	}
+
+	for testName, testData := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			preparer := NewCommitMessagePreparer(prjCfg)
+
+			preparedCommitMessage, err := preparer.PrepareGitCommitMessage(editorMessage, testData.branchName, testData.source)
+
+			assert.NoError(t, err)
+			assert.Exactly(t, testData.output, preparedCommitMessage)
+		})
+	}
+}
+
+func TestPrepareCommitMessage_HappyPath(t *testing.T) {
+	defer restoreRewriteOriginals()
+
+	commitMessageFileCanBeRead(t)
+	commitMessageIsWrittenToFile(t)
+
+	err := PrepareCommitMessage(commitMessageFile, "", NewCommitMessagePreparer(config.Project{}))
+
+	assert.NoError(t, err)
+}
+
+func TestPrepareCommitMessage_ErrorCases(t *testing.T) {
+	defer restoreRewriteOriginals()
+
+	commitMessageFileCannotBeFound(t)
+	err := PrepareCommitMessage(commitMessageFile, "", NewCommitMessagePreparer(config.Project{}))
+	assert.Contains(t, err.Error(), "error reading commit message")
+
+	commitMessageFileCanBeRead(t)
+	err = PrepareCommitMessage(commitMessageFile, "", &commitMessagePreparerStub{errors.New("some error")})
+	assert.Contains(t, err.Error(), "error preparing commit message: some error")
+
+	writeFileFunc = func(string, []byte, os.FileMode) error { return errors.New("cannot write to file") }
+	err = PrepareCommitMessage(commitMessageFile, "", NewCommitMessagePreparer(config.Project{}))
+	assert.Contains(t, err.Error(), "error writing commit message to")
+}
+
+type commitMessagePreparerStub struct {
+	err error
+}
+
+func (p *commitMessagePreparerStub) PrepareGitCommitMessage(string, string, string) (string, error) {
+	return "", p.err
+}
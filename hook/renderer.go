@@ -2,9 +2,11 @@ package hook
 
 import (
 	"bytes"
+	"strings"
 	"text/template"
 
 	"github.com/Oppodelldog/git-commit-hook/config"
+	"github.com/Oppodelldog/git-commit-hook/templatefuncs"
 )
 
 // CommitMessageRenderer implements rendering a commit message by a given
@@ -24,23 +26,149 @@ type commitMessageRenderer struct {
 	projConf config.Project
 }
 
-// Render renders a commit message using the template defined for the given resolveBranchNameFunc
+// commitMessageMarker is rendered in place of the commit message to find out what a template adds around the message
+const commitMessageMarker = "\x00commit-message\x00"
+
+// Render renders a commit message using the template defined for the branch type of the given view model.
+// The branch type is not matched again, it is taken from the view model as the branch type resolver set it.
+// An empty branch type stands for a branch that matched no branch type and gets the template of all branch types.
+// If the commit message already looks like the result of the template, it is returned unchanged. This keeps
+// messages stable on amend, rebase or when reusing the message of another commit.
 func (r *commitMessageRenderer) Render(viewModel ViewModel) (string, error) {
-	branchType := r.projConf.GetBranchType(viewModel.BranchName)
-	commitMessageTemplate := r.getTemplate(branchType)
+	commitMessageTemplate := r.getTemplate(viewModel.BranchType)
 	if commitMessageTemplate == "" {
 		commitMessageTemplate = getFallbackCommitMessageTemplate()
 	}
-	tmpl, err := template.New("commitMessageTemplate").Parse(commitMessageTemplate)
+	tmpl, err := template.New("commitMessageTemplate").Option("missingkey=zero").
+		Funcs(templatefuncs.FuncMap(r.projConf.RegexEngine)).
+		Parse(commitMessageTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	unwrappedViewModel, alreadyRendered, err := unwrapCommitMessage(tmpl, viewModel)
 	if err != nil {
 		return "", err
 	}
+	if alreadyRendered {
+		return viewModel.CommitMessage, nil
+	}
+
+	return execute(tmpl, unwrappedViewModel)
+}
+
+func execute(tmpl *template.Template, viewModel ViewModel) (string, error) {
 	buffer := bytes.NewBufferString("")
-	err = tmpl.Execute(buffer, viewModel)
+	err := tmpl.Execute(buffer, viewModel)
 
 	return buffer.String(), err
 }
 
+// unwrapCommitMessage removes what the template puts around the commit message from the view model, so it is not
+// added twice. What comes before and after the message are handled separately, a message that has just one of them
+// gets only the missing one when it is rendered. Trailers at the end of the message are ignored. Templates that
+// rebuild the message from its parts are checked by what they put around the subject within the subject line.
+// If the message already has everything the template puts around it, alreadyRendered is true.
+func unwrapCommitMessage(tmpl *template.Template, viewModel ViewModel) (ViewModel, bool, error) {
+	markerViewModel := viewModel
+	markerViewModel.CommitMessage = commitMessageMarker
+	prefix, suffix, found, err := renderAroundMarker(tmpl, markerViewModel)
+	if err != nil {
+		return viewModel, false, err
+	}
+	if found {
+		message, trailerLines := viewModel.CommitMessage, []string(nil)
+		if _, hasSuffix := trimRenderedSuffix(message, suffix); !hasSuffix {
+			head, _, lines := splitTrailerBlock(message)
+			if _, headHasSuffix := trimRenderedSuffix(head, suffix); headHasSuffix {
+				message, trailerLines = head, lines
+			}
+		}
+
+		core, alreadyRendered, unwrapped := unwrap(message, prefix, suffix)
+		if alreadyRendered || !unwrapped {
+			return viewModel, alreadyRendered, nil
+		}
+		viewModel.CommitMessage = joinTrailerBlock(core, trailerLines)
+		viewModel.Subject, viewModel.Body, viewModel.Trailers = splitCommitMessage(viewModel.CommitMessage)
+
+		return viewModel, false, nil
+	}
+
+	markerViewModel = viewModel
+	markerViewModel.Subject = commitMessageMarker
+	prefix, suffix, found, err = renderAroundMarker(tmpl, markerViewModel)
+	if err != nil || !found {
+		return viewModel, false, err
+	}
+	prefix = prefix[strings.LastIndex(prefix, "\n")+1:]
+	suffix = strings.SplitN(suffix, "\n", 2)[0]
+
+	subject, alreadyRendered, unwrapped := unwrap(viewModel.Subject, prefix, suffix)
+	if alreadyRendered || !unwrapped {
+		return viewModel, alreadyRendered, nil
+	}
+	viewModel.CommitMessage = subject + strings.TrimPrefix(viewModel.CommitMessage, viewModel.Subject)
+	viewModel.Subject = subject
+
+	return viewModel, false, nil
+}
+
+// unwrap removes prefix and suffix from s, each of them only if s has it. Leading and trailing whitespace of them is
+// ignored. complete tells if s has all of the non-empty ones, unwrapped tells if s had some, but not all of them.
+func unwrap(s string, prefix string, suffix string) (core string, complete bool, unwrapped bool) {
+	prefix = strings.TrimLeft(prefix, " \t\r\n")
+	suffix = strings.TrimRight(suffix, " \t\r\n")
+	if prefix == "" && suffix == "" {
+		return s, false, false
+	}
+
+	core, hasPrefix := trimRenderedPrefix(s, prefix)
+	core, hasSuffix := trimRenderedSuffix(core, suffix)
+	complete = (prefix == "" || hasPrefix) && (suffix == "" || hasSuffix)
+	if complete || !hasPrefix && !hasSuffix {
+		return s, complete, false
+	}
+
+	return strings.TrimSpace(core), false, true
+}
+
+// trimRenderedPrefix removes the given prefix from s, if s has more than it. Leading whitespace of prefix is ignored.
+func trimRenderedPrefix(s string, prefix string) (string, bool) {
+	prefix = strings.TrimLeft(prefix, " \t\r\n")
+	if prefix == "" || len(s) <= len(prefix) || !strings.HasPrefix(s, prefix) {
+		return s, false
+	}
+
+	return s[len(prefix):], true
+}
+
+// trimRenderedSuffix removes the given suffix from s, if s has more than it. Trailing whitespace of suffix is ignored.
+func trimRenderedSuffix(s string, suffix string) (string, bool) {
+	suffix = strings.TrimRight(suffix, " \t\r\n")
+	if suffix == "" || len(s) <= len(suffix) || !strings.HasSuffix(s, suffix) {
+		return s, false
+	}
+
+	return s[:len(s)-len(suffix)], true
+}
+
+// renderAroundMarker renders the template and returns what it puts before and after the marker.
+// If the marker is not rendered exactly once, found is false.
+func renderAroundMarker(tmpl *template.Template, markerViewModel ViewModel) (prefix string, suffix string, found bool, err error) {
+	renderedMarker, err := execute(tmpl, markerViewModel)
+	if err != nil {
+		return "", "", false, err
+	}
+
+	parts := strings.Split(renderedMarker, commitMessageMarker)
+	if len(parts) != 2 {
+		return "", "", false, nil
+	}
+
+	return parts[0], parts[1], true, nil
+}
+
 func getFallbackCommitMessageTemplate() string {
 	return "{{.CommitMessage}}"
 }
@@ -13,14 +13,14 @@ func TestRenderCommitMessage(t *testing.T) {
 		CommitMessage: "initial commit",
 	}
 	cfg := &config.Project{
-		BranchTypes: map[string]config.BranchTypePattern{
-			"feature": `^(origin\/)*feature/.*$`,
+		BranchTypes: config.BranchTypes{
+			{Name: "feature", Pattern: `^(origin\/)*feature/.*$`},
 		},
 		Templates: map[string]config.BranchTypeTemplate{
 			"feature": "{{.BranchName}}: {{.CommitMessage}}",
 		}}
 	renderer := commitMessageRenderer{*cfg}
-	modifiedCommitMessage, err := renderer.Render(viewModel)
+	modifiedCommitMessage, err := renderer.Render(resolveBranchType(t, *cfg, viewModel))
 	if err != nil {
 		t.Fatalf("Did not expect Render to return an error, but got: %v ", err)
 	}
@@ -33,15 +33,15 @@ func TestRenderCommitMessage_InvalidTemplate_ReturnsError(t *testing.T) {
 	invalidTemplate := "{{{{{ HELLO"
 	viewModel := ViewModel{}
 	cfg := &config.Project{
-		BranchTypes: map[string]config.BranchTypePattern{
-			"feature": `.*`,
+		BranchTypes: config.BranchTypes{
+			{Name: "feature", Pattern: `.*`},
 		},
 		Templates: map[string]config.BranchTypeTemplate{
 			"feature": config.BranchTypeTemplate(invalidTemplate),
 		}}
 
 	renderer := commitMessageRenderer{*cfg}
-	_, err := renderer.Render(viewModel)
+	_, err := renderer.Render(resolveBranchType(t, *cfg, viewModel))
 
 	assert.Contains(t, err.Error(), "template:")
 }
@@ -52,13 +52,13 @@ func TestRenderCommitMessage_NoTemplateFound_PassesBackTheGivenCommitMessage(t *
 		CommitMessage: givenCommitMessage,
 	}
 	cfg := &config.Project{
-		BranchTypes: map[string]config.BranchTypePattern{
-			"feature": `.*`,
+		BranchTypes: config.BranchTypes{
+			{Name: "feature", Pattern: `.*`},
 		},
 		Templates: map[string]config.BranchTypeTemplate{}}
 
 	renderer := commitMessageRenderer{*cfg}
-	modifiedCommitMessage, err := renderer.Render(viewModel)
+	modifiedCommitMessage, err := renderer.Render(resolveBranchType(t, *cfg, viewModel))
 	if err != nil {
 		t.Fatalf("Did not expect Render to return an error, but got: %v ", err)
 	}
@@ -92,14 +92,194 @@ func TestRenderCommitMessage_NoBranchConfiguration_PassesBackTheGivenCommitMessa
 		CommitMessage: givenCommitMessage,
 	}
 	cfg := &config.Project{
-		BranchTypes: map[string]config.BranchTypePattern{},
+		BranchTypes: config.BranchTypes{},
 		Templates:   map[string]config.BranchTypeTemplate{}}
 
 	renderer := commitMessageRenderer{*cfg}
-	modifiedCommitMessage, err := renderer.Render(viewModel)
+	modifiedCommitMessage, err := renderer.Render(resolveBranchType(t, *cfg, viewModel))
 	if err != nil {
 		t.Fatalf("Did not expect Render to return an error, but got: %v ", err)
 	}
 
 	assert.Exactly(t, givenCommitMessage, modifiedCommitMessage)
 }
+
+func TestRenderCommitMessage_NamedCaptureGroupsOfBranchPattern(t *testing.T) {
+	viewModel := ViewModel{
+		BranchName:    "feature/PROJECT-123-add-login",
+		CommitMessage: "initial commit",
+	}
+	cfg := &config.Project{
+		BranchTypes: config.BranchTypes{
+			{Name: "feature", Pattern: `^feature/(?P<ticket>[A-Z]+-[0-9]+)-(?P<topic>.*)$`},
+		},
+		Templates: map[string]config.BranchTypeTemplate{
+			"feature": "{{.Branch.ticket}}: {{.CommitMessage}} ({{.Branch.topic}}){{.Branch.unknown}}",
+		}}
+	renderer := commitMessageRenderer{*cfg}
+	modifiedCommitMessage, err := renderer.Render(resolveBranchType(t, *cfg, viewModel))
+	if err != nil {
+		t.Fatalf("Did not expect Render to return an error, but got: %v ", err)
+	}
+
+	assert.Exactly(t, "PROJECT-123: initial commit (add-login)", modifiedCommitMessage)
+}
+
+func TestRenderCommitMessage_AlreadyRendered_PassesBackTheGivenCommitMessage(t *testing.T) {
+	cfg := &config.Project{
+		BranchTypes: config.BranchTypes{
+			{Name: "feature", Pattern: `^feature/(?P<ticket>[A-Z]+-[0-9]+)$`},
+		},
+		Templates: map[string]config.BranchTypeTemplate{
+			"feature": "{{.BranchName}}: {{.CommitMessage}}\n\nRefs: {{.Branch.ticket}}\n",
+		}}
+	renderer := commitMessageRenderer{*cfg}
+
+	testDataSet := map[string]struct {
+		commitMessage         string
+		expectedCommitMessage string
+	}{
+		"new message is rendered": {
+			commitMessage:         "initial commit",
+			expectedCommitMessage: "feature/PROJECT-1: initial commit\n\nRefs: PROJECT-1\n",
+		},
+		"amended message is not rendered again": {
+			commitMessage:         "feature/PROJECT-1: initial commit\n\nRefs: PROJECT-1",
+			expectedCommitMessage: "feature/PROJECT-1: initial commit\n\nRefs: PROJECT-1",
+		},
+		"message with prefix only gets the missing suffix": {
+			commitMessage:         "feature/PROJECT-1: initial commit",
+			expectedCommitMessage: "feature/PROJECT-1: initial commit\n\nRefs: PROJECT-1\n",
+		},
+		"message with suffix only gets the missing prefix": {
+			commitMessage:         "initial commit\n\nRefs: PROJECT-1",
+			expectedCommitMessage: "feature/PROJECT-1: initial commit\n\nRefs: PROJECT-1\n",
+		},
+	}
+
+	for testCaseName, testData := range testDataSet {
+		t.Run(testCaseName, func(t *testing.T) {
+			viewModel := ViewModel{BranchName: "feature/PROJECT-1", CommitMessage: testData.commitMessage}
+
+			modifiedCommitMessage, err := renderer.Render(resolveBranchType(t, *cfg, viewModel))
+
+			assert.NoError(t, err)
+			assert.Exactly(t, testData.expectedCommitMessage, modifiedCommitMessage)
+		})
+	}
+}
+
+func TestRenderCommitMessage_AlreadyRenderedWithTrailers_PassesBackTheGivenCommitMessage(t *testing.T) {
+	cfg := &config.Project{
+		Templates: map[string]config.BranchTypeTemplate{
+			"*": "{{.CommitMessage}} [{{.BranchName}}]",
+		}}
+	renderer := commitMessageRenderer{*cfg}
+	commitMessage := "initial commit [develop]\n\nSigned-off-by: Jane Doe <jane@example.com>"
+
+	modifiedCommitMessage, err := renderer.Render(resolveBranchType(t, *cfg, ViewModel{BranchName: "develop", CommitMessage: commitMessage}))
+
+	assert.NoError(t, err)
+	assert.Exactly(t, commitMessage, modifiedCommitMessage)
+}
+
+func TestRenderCommitMessage_TemplateWithoutCommitMessage_IsRenderedEveryTime(t *testing.T) {
+	cfg := &config.Project{
+		Templates: map[string]config.BranchTypeTemplate{
+			"*": "{{.BranchName}}",
+		}}
+	renderer := commitMessageRenderer{*cfg}
+
+	modifiedCommitMessage, err := renderer.Render(resolveBranchType(t, *cfg, ViewModel{BranchName: "develop", CommitMessage: "develop"}))
+
+	assert.NoError(t, err)
+	assert.Exactly(t, "develop", modifiedCommitMessage)
+}
+
+func TestRenderCommitMessage_TemplateFunctions(t *testing.T) {
+	cfg := &config.Project{
+		RegexEngine: "pcre",
+		BranchTypes: config.BranchTypes{
+			{Name: "feature", Pattern: `^feature/`},
+		},
+		Templates: map[string]config.BranchTypeTemplate{
+			"feature": `{{.BranchName | ticketFromBranch | default "NO-TICKET"}} {{.BranchName | regexFind "(?<=-)[a-z-]+$"}}: {{.CommitMessage | firstLine | lower}}`,
+		}}
+	renderer := commitMessageRenderer{*cfg}
+
+	testDataSet := map[string]struct {
+		branchName            string
+		expectedCommitMessage string
+	}{
+		"with ticket":    {branchName: "feature/PROJECT-1-login", expectedCommitMessage: "PROJECT-1 login: add form"},
+		"without ticket": {branchName: "feature/login", expectedCommitMessage: "NO-TICKET : add form"},
+	}
+
+	for testCaseName, testData := range testDataSet {
+		t.Run(testCaseName, func(t *testing.T) {
+			modifiedCommitMessage, err := renderer.Render(resolveBranchType(t, *cfg, ViewModel{BranchName: testData.branchName, CommitMessage: "Add Form\n\nbody"}))
+
+			assert.NoError(t, err)
+			assert.Exactly(t, testData.expectedCommitMessage, modifiedCommitMessage)
+		})
+	}
+}
+
+func TestRenderCommitMessage_SubjectBodyAndTrailers(t *testing.T) {
+	defer restoreViewModelOriginals()
+	withoutRepositoryState()
+	cfg := &config.Project{
+		BranchTypes: config.BranchTypes{
+			{Name: "feature", Pattern: `^feature/(?P<ticket>[A-Z]+-[0-9]+)$`},
+		},
+		Templates: map[string]config.BranchTypeTemplate{
+			"feature": "{{.Branch.ticket}}: {{.Subject}}\n{{if .Body}}\n{{.Body}}\n{{end}}\n{{range .Trailers}}{{.}}\n{{end}}Refs: {{.Branch.ticket}}\n",
+		}}
+	renderer := commitMessageRenderer{*cfg}
+
+	testDataSet := map[string]struct {
+		commitMessage         string
+		expectedCommitMessage string
+	}{
+		"subject only": {
+			commitMessage:         "add login form",
+			expectedCommitMessage: "PROJECT-1: add login form\n\nRefs: PROJECT-1\n",
+		},
+		"body and trailers": {
+			commitMessage:         "add login form\n\nvalidates the email\n\nSigned-off-by: Jane Doe <jane@example.com>",
+			expectedCommitMessage: "PROJECT-1: add login form\n\nvalidates the email\n\nSigned-off-by: Jane Doe <jane@example.com>\nRefs: PROJECT-1\n",
+		},
+		"amended message is not rendered again": {
+			commitMessage:         "PROJECT-1: add login form\n\nRefs: PROJECT-1",
+			expectedCommitMessage: "PROJECT-1: add login form\n\nRefs: PROJECT-1",
+		},
+	}
+
+	for testCaseName, testData := range testDataSet {
+		t.Run(testCaseName, func(t *testing.T) {
+			modifiedCommitMessage, err := renderer.Render(resolveBranchType(t, *cfg, createViewModel(testData.commitMessage, "feature/PROJECT-1")))
+
+			assert.NoError(t, err)
+			assert.Exactly(t, testData.expectedCommitMessage, modifiedCommitMessage)
+		})
+	}
+}
+
+func TestRenderCommitMessage_SubjectWithPrefixOnly_GetsTheMissingSuffix(t *testing.T) {
+	cfg := &config.Project{
+		BranchTypes: config.BranchTypes{
+			{Name: "feature", Pattern: `^feature/(?P<ticket>[A-Z]+-[0-9]+)$`},
+		},
+		Templates: map[string]config.BranchTypeTemplate{
+			"feature": "[{{.Branch.ticket}}] {{.Subject}} ({{.BranchType}})\n{{if .Body}}\n{{.Body}}\n{{end}}",
+		}}
+	renderer := commitMessageRenderer{*cfg}
+	commitMessage := "[PROJECT-1] add login form\n\nvalidates the email"
+	subject, body, trailers := splitCommitMessage(commitMessage)
+	viewModel := ViewModel{BranchName: "feature/PROJECT-1", CommitMessage: commitMessage, Subject: subject, Body: body, Trailers: trailers}
+
+	modifiedCommitMessage, err := renderer.Render(resolveBranchType(t, *cfg, viewModel))
+
+	assert.NoError(t, err)
+	assert.Exactly(t, "[PROJECT-1] add login form (feature)\n\nvalidates the email\n", modifiedCommitMessage)
+}
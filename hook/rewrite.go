@@ -5,18 +5,21 @@ import (
 
 	"os"
 
+	"github.com/Oppodelldog/git-commit-hook/config"
 	"github.com/Oppodelldog/git-commit-hook/git"
 	"github.com/pkg/errors"
 )
 
 type (
-	readFileFuncDef  func(filename string) ([]byte, error)
-	writeFileFuncDef func(string, []byte, os.FileMode) error
+	readFileFuncDef             func(filename string) ([]byte, error)
+	writeFileFuncDef            func(string, []byte, os.FileMode) error
+	getCurrentBranchNameFuncDef func() (string, error)
 )
 
 var (
-	readFileFunc  = readFileFuncDef(ioutil.ReadFile)
-	writeFileFunc = writeFileFuncDef(ioutil.WriteFile)
+	readFileFunc             = readFileFuncDef(ioutil.ReadFile)
+	writeFileFunc            = writeFileFuncDef(ioutil.WriteFile)
+	getCurrentBranchNameFunc = getCurrentBranchNameFuncDef(git.GetCurrentBranchName)
 )
 
 //RewriteCommitMessage rewrites the commit message in the given commit message file
@@ -28,8 +31,7 @@ func RewriteCommitMessage(commitMessageFile string, commitMessageModifier Commit
 		return errors.Errorf("error reading commit message from '%s': %v", commitMessageFile, err.Error())
 	}
 
-	branchName, _ := git.GetCurrentBranchName()
-	outputMessage, err = commitMessageModifier.ModifyGitCommitMessage(string(fileContent), branchName)
+	outputMessage, err = commitMessageModifier.ModifyGitCommitMessage(string(fileContent), getBranchName())
 	if err != nil {
 		return errors.Errorf("error modifying commit message: %s", err.Error())
 	}
@@ -41,3 +43,14 @@ func RewriteCommitMessage(commitMessageFile string, commitMessageModifier Commit
 
 	return nil
 }
+
+// getBranchName returns the branch name set by GIT_COMMIT_HOOK_BRANCH or else the current git branch.
+func getBranchName() string {
+	if branchName := config.GetBranchNameFromEnvironment(); branchName != "" {
+		return branchName
+	}
+
+	branchName, _ := getCurrentBranchNameFunc()
+
+	return branchName
+}
@@ -13,16 +13,19 @@ const commitMessageFile = ".git/COMMIT_MSG_EDIT"
 const commitMessage = "commitMessage"
 
 var rewriteOriginals = struct {
-	readFileFunc  readFileFuncDef
-	writeFileFunc writeFileFuncDef
+	readFileFunc             readFileFuncDef
+	writeFileFunc            writeFileFuncDef
+	getCurrentBranchNameFunc getCurrentBranchNameFuncDef
 }{
-	readFileFunc:  readFileFunc,
-	writeFileFunc: writeFileFunc,
+	readFileFunc:             readFileFunc,
+	writeFileFunc:            writeFileFunc,
+	getCurrentBranchNameFunc: getCurrentBranchNameFunc,
 }
 
 func restoreRewriteOriginals() {
 	readFileFunc = rewriteOriginals.readFileFunc
 	writeFileFunc = rewriteOriginals.writeFileFunc
+	getCurrentBranchNameFunc = rewriteOriginals.getCurrentBranchNameFunc
 }
 
 func TestRewriteCommitMessage_ErrorCase_CannotFindCommitMessageFile(t *testing.T) {
@@ -79,6 +82,39 @@ func TestRewriteCommitMessage_HappyPath(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestRewriteCommitMessage_BranchNameIsOverriddenByEnvironment(t *testing.T) {
+	defer restoreRewriteOriginals()
+	defer os.Unsetenv(config.EnvBranch)
+
+	commitMessageFileCanBeRead(t)
+	writeFileFunc = func(fileName string, data []byte, perm os.FileMode) error {
+		assert.Exactly(t, "feature/PROJECT-123: "+commitMessage, string(data))
+		return nil
+	}
+	getCurrentBranchNameFunc = func() (string, error) { return "master", nil }
+	os.Setenv(config.EnvBranch, "feature/PROJECT-123")
+	modifier := NewCommitMessageModifier(config.Project{
+		BranchTypes: config.BranchTypes{{Name: "feature", Pattern: "^feature/.*$"}},
+		Templates:   map[string]config.BranchTypeTemplate{"feature": "{{.BranchName}}: {{.CommitMessage}}"},
+	})
+
+	err := RewriteCommitMessage(commitMessageFile, modifier)
+
+	assert.NoError(t, err)
+}
+
+func TestGetBranchName(t *testing.T) {
+	defer restoreRewriteOriginals()
+	defer os.Unsetenv(config.EnvBranch)
+	getCurrentBranchNameFunc = func() (string, error) { return "master", nil }
+
+	assert.Exactly(t, "master", getBranchName())
+
+	os.Setenv(config.EnvBranch, "release/1.0")
+
+	assert.Exactly(t, "release/1.0", getBranchName())
+}
+
 func cannotWriteToFile(t *testing.T) {
 	writeFileFunc = func(fileName string, data []byte, perm os.FileMode) error {
 		assert.Exactly(t, commitMessageFile, fileName)
@@ -0,0 +1,110 @@
+package hook
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Oppodelldog/git-commit-hook/config"
+	"github.com/Oppodelldog/git-commit-hook/regexadapter"
+)
+
+// evaluateRule evaluates the given rule against the commit message and returns a description of every failed rule.
+// Patterns are compiled by the given regex engine. If a pattern of the rule is invalid, an error is returned.
+func evaluateRule(rule config.ValidationRule, commitMessage string, regexEngine string) ([]string, error) {
+	var failures []string
+
+	if rule.Pattern != "" {
+		matches, err := regexadapter.RegexMatchesString(regexEngine, rule.Pattern, commitMessage)
+		if err != nil {
+			return nil, err
+		}
+		if !matches {
+			failures = append(failures, describeRule(rule))
+		}
+	}
+
+	if rule.SubjectMaxLength > 0 && utf8.RuneCountInString(getSubjectLine(commitMessage)) > rule.SubjectMaxLength {
+		failures = append(failures, describeRule(rule))
+	}
+
+	for _, childRule := range rule.AllOf {
+		childFailures, err := evaluateRule(childRule, commitMessage, regexEngine)
+		if err != nil {
+			return nil, err
+		}
+		failures = append(failures, childFailures...)
+	}
+
+	if len(rule.AnyOf) > 0 {
+		passes, err := anyRulePasses(rule.AnyOf, commitMessage, regexEngine)
+		if err != nil {
+			return nil, err
+		}
+		if !passes {
+			var descriptions []string
+			for _, childRule := range rule.AnyOf {
+				descriptions = append(descriptions, describeRule(childRule))
+			}
+			failures = append(failures, fmt.Sprintf("at least one of: %s", strings.Join(descriptions, ", ")))
+		}
+	}
+
+	for _, childRule := range rule.NoneOf {
+		childFailures, err := evaluateRule(childRule, commitMessage, regexEngine)
+		if err != nil {
+			return nil, err
+		}
+		if len(childFailures) == 0 {
+			failures = append(failures, describeForbiddenRule(childRule))
+		}
+	}
+
+	return failures, nil
+}
+
+func anyRulePasses(rules []config.ValidationRule, commitMessage string, regexEngine string) (bool, error) {
+	for _, rule := range rules {
+		failures, err := evaluateRule(rule, commitMessage, regexEngine)
+		if err != nil {
+			return false, err
+		}
+		if len(failures) == 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func describeRule(rule config.ValidationRule) string {
+	switch {
+	case rule.Description != "":
+		return rule.Description
+	case rule.Pattern != "":
+		return fmt.Sprintf("match '%s'", rule.Pattern)
+	case rule.SubjectMaxLength > 0:
+		return fmt.Sprintf("have a subject line of at most %d characters", rule.SubjectMaxLength)
+	default:
+		return "pass nested rules"
+	}
+}
+
+// describeForbiddenRule describes a rule of noneOf that passed. Its description tells what the message must not do,
+// like 'not contain WIP', so it is shown as is.
+func describeForbiddenRule(rule config.ValidationRule) string {
+	switch {
+	case rule.Description != "":
+		return rule.Description
+	case rule.Pattern != "":
+		return fmt.Sprintf("not match '%s'", rule.Pattern)
+	case rule.SubjectMaxLength > 0:
+		return fmt.Sprintf("have a subject line of more than %d characters", rule.SubjectMaxLength)
+	default:
+		return "not pass nested rules"
+	}
+}
+
+func getSubjectLine(commitMessage string) string {
+	return strings.SplitN(commitMessage, "\n", 2)[0]
+}
@@ -0,0 +1,100 @@
+package hook
+
+import (
+	"testing"
+
+	"github.com/Oppodelldog/git-commit-hook/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateRule(t *testing.T) {
+	releaseRule := config.ValidationRule{
+		AllOf: []config.ValidationRule{
+			{Pattern: `(?m)(?:\s|^|/)(([A-Z](_)*)+-[0-9]+)([\s,;:!.-]|$)`, Description: "contain a ticket ID"},
+			{SubjectMaxLength: 20},
+		},
+		NoneOf: []config.ValidationRule{
+			{Pattern: `WIP`, Description: "not contain WIP"},
+		},
+	}
+
+	testDataSet := map[string]struct {
+		rule             config.ValidationRule
+		commitMessage    string
+		expectedFailures []string
+	}{
+		"all rules pass": {
+			rule:          releaseRule,
+			commitMessage: "PROJECT-1 fix it\n\nlong body text is not part of the subject",
+		},
+		"every failed rule is reported": {
+			rule:          releaseRule,
+			commitMessage: "WIP: a subject line that is too long",
+			expectedFailures: []string{
+				"contain a ticket ID",
+				"have a subject line of at most 20 characters",
+				"not contain WIP",
+			},
+		},
+		"any of passes": {
+			rule: config.ValidationRule{
+				AnyOf: []config.ValidationRule{{Pattern: "A"}, {Pattern: "B"}},
+			},
+			commitMessage: "B",
+		},
+		"any of fails": {
+			rule: config.ValidationRule{
+				AnyOf: []config.ValidationRule{{Pattern: "A"}, {Pattern: "B", Description: "contain B"}},
+			},
+			commitMessage:    "C",
+			expectedFailures: []string{"at least one of: match 'A', contain B"},
+		},
+		"none of without descriptions": {
+			rule: config.ValidationRule{
+				NoneOf: []config.ValidationRule{
+					{Pattern: "WIP"},
+					{SubjectMaxLength: 72},
+					{AllOf: []config.ValidationRule{{Pattern: "fixup"}}},
+				},
+			},
+			commitMessage: "WIP fixup",
+			expectedFailures: []string{
+				"not match 'WIP'",
+				"have a subject line of more than 72 characters",
+				"not pass nested rules",
+			},
+		},
+		"nested groups": {
+			rule: config.ValidationRule{
+				AnyOf: []config.ValidationRule{
+					{Pattern: "@noissue"},
+					{
+						Description: "a ticket ID without WIP",
+						AllOf:       []config.ValidationRule{{Pattern: "[A-Z]+-[0-9]+"}},
+						NoneOf:      []config.ValidationRule{{Pattern: "WIP"}},
+					},
+				},
+			},
+			commitMessage:    "WIP PROJECT-1",
+			expectedFailures: []string{"at least one of: match '@noissue', a ticket ID without WIP"},
+		},
+	}
+
+	for testCaseName, testData := range testDataSet {
+		t.Run(testCaseName, func(t *testing.T) {
+			failures, err := evaluateRule(testData.rule, testData.commitMessage, "")
+
+			assert.NoError(t, err)
+			assert.Exactly(t, testData.expectedFailures, failures)
+		})
+	}
+}
+
+func TestEvaluateRule_InvalidPattern_ReturnsError(t *testing.T) {
+	rule := config.ValidationRule{AnyOf: []config.ValidationRule{{Pattern: `@noissue`}, {Pattern: `(PROJECT-[0-9]+`}}}
+
+	failures, err := evaluateRule(rule, "PROJECT-1", "")
+
+	assert.Nil(t, failures)
+	assert.EqualError(t, err, "invalid pattern '(PROJECT-[0-9]+': error parsing regexp: missing closing ): `(PROJECT-[0-9]+`")
+}
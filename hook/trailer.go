@@ -0,0 +1,189 @@
+package hook
+
+import (
+	"regexp"
+	"strings"
+)
+
+// trailerPattern matches a trailer line like 'Signed-off-by: Jane Doe <jane@example.com>'. Like git, the key consists
+// of alphanumeric characters and hyphens and may be followed by whitespace before the separator.
+var trailerPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9-]*)[ \t]*:[ \t]*(.*?)[ \t]*$`)
+
+// cherryPickedPrefix starts the line git cherry-pick -x adds, git counts it as trailer although it has no key
+const cherryPickedPrefix = "(cherry picked from commit "
+
+// gitGeneratedPrefixes are the beginnings of the lines git writes into trailer blocks itself
+var gitGeneratedPrefixes = []string{"Signed-off-by: ", cherryPickedPrefix}
+
+// Trailer is a key value pair at the end of a commit message, like 'Refs: PROJECT-123'.
+// Lines of a trailer block that are no trailers, like '(cherry picked from commit ...)', have no key.
+type Trailer struct {
+	Key   string
+	Value string
+}
+
+// String returns the trailer the way it is written into a commit message
+func (t Trailer) String() string {
+	if t.Key == "" {
+		return t.Value
+	}
+
+	return t.Key + ": " + t.Value
+}
+
+// splitCommitMessage splits the given cleaned up commit message into its subject, which is the first paragraph,
+// the body and the trailers. The trailers are the last paragraph of the message if it is not the subject and it is
+// a trailer block.
+func splitCommitMessage(commitMessage string) (subject string, body string, trailers []Trailer) {
+	paragraphs := splitParagraphs(commitMessage)
+	if len(paragraphs) == 0 {
+		return "", "", nil
+	}
+
+	subject = paragraphs[0]
+	bodyParagraphs := paragraphs[1:]
+	if len(bodyParagraphs) > 0 {
+		trailers, _ = parseTrailerBlock(bodyParagraphs[len(bodyParagraphs)-1])
+		if trailers != nil {
+			bodyParagraphs = bodyParagraphs[:len(bodyParagraphs)-1]
+		}
+	}
+	body = strings.Join(bodyParagraphs, "\n\n")
+
+	return subject, body, trailers
+}
+
+// splitParagraphs splits the given text at blank lines. Paragraphs keep their lines, blank lines are removed.
+func splitParagraphs(text string) []string {
+	var paragraphs []string
+	var lines []string
+	for _, line := range strings.Split(strings.Replace(text, "\r\n", "\n", -1), "\n") {
+		if strings.TrimSpace(line) == "" {
+			if len(lines) > 0 {
+				paragraphs = append(paragraphs, strings.Join(lines, "\n"))
+				lines = nil
+			}
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) > 0 {
+		paragraphs = append(paragraphs, strings.Join(lines, "\n"))
+	}
+
+	return paragraphs
+}
+
+// parseTrailerBlock parses the given paragraph the way git interpret-trailers does. It is a trailer block if all of
+// its lines are trailers, or if it contains a line generated by git, like 'Signed-off-by: ', and at least a quarter
+// of its lines are trailers. Continuation lines, which start with whitespace, are appended to the value of the
+// trailer before, separated by a space. Along with the trailers their lines are returned as written, including
+// continuation lines. If the paragraph is no trailer block, nil is returned.
+func parseTrailerBlock(paragraph string) ([]Trailer, []string) {
+	var trailers []Trailer
+	var trailerTexts []string
+	var trailerLines, nonTrailerLines int
+	var hasGitGeneratedLine bool
+	for _, line := range strings.Split(paragraph, "\n") {
+		if isContinuationLine(line) && len(trailers) > 0 && trailers[len(trailers)-1].Key != "" {
+			trailers[len(trailers)-1].Value += " " + strings.TrimSpace(line)
+			trailerTexts[len(trailerTexts)-1] += "\n" + line
+			continue
+		}
+		trailerTexts = append(trailerTexts, line)
+
+		if hasGitGeneratedPrefix(line) {
+			hasGitGeneratedLine = true
+		}
+
+		if matches := trailerPattern.FindStringSubmatch(line); matches != nil {
+			trailers = append(trailers, Trailer{Key: matches[1], Value: matches[2]})
+			trailerLines++
+			continue
+		}
+
+		trailers = append(trailers, Trailer{Value: line})
+		if strings.HasPrefix(line, cherryPickedPrefix) {
+			trailerLines++
+		} else {
+			nonTrailerLines++
+		}
+	}
+
+	if trailerLines > 0 && (nonTrailerLines == 0 || hasGitGeneratedLine && trailerLines*3 >= nonTrailerLines) {
+		return trailers, trailerTexts
+	}
+
+	return nil, nil
+}
+
+func isContinuationLine(line string) bool {
+	return strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+}
+
+func hasGitGeneratedPrefix(line string) bool {
+	for _, prefix := range gitGeneratedPrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitTrailerBlock splits the given commit message into the text before its trailer block, the trailers and their
+// lines as written. If the message has no trailer block, it is returned unchanged.
+func splitTrailerBlock(commitMessage string) (string, []Trailer, []string) {
+	lines := strings.Split(strings.TrimRight(strings.Replace(commitMessage, "\r\n", "\n", -1), " \t\r\n"), "\n")
+	for i := len(lines) - 1; i > 0; i-- {
+		if strings.TrimSpace(lines[i]) != "" {
+			continue
+		}
+
+		head := strings.TrimRight(strings.Join(lines[:i], "\n"), " \t\r\n")
+		trailers, trailerLines := parseTrailerBlock(strings.Join(lines[i+1:], "\n"))
+		if head == "" || trailers == nil {
+			break
+		}
+
+		return head, trailers, trailerLines
+	}
+
+	return commitMessage, nil, nil
+}
+
+// joinTrailerBlock appends the given trailer lines as last paragraph to the given text.
+func joinTrailerBlock(head string, trailerLines []string) string {
+	if len(trailerLines) == 0 {
+		return head
+	}
+
+	return strings.TrimRight(head, " \t\r\n") + "\n\n" + strings.Join(trailerLines, "\n")
+}
+
+// isDuplicateTrailer tells if a trailer of the same key and value was seen before and marks the given one as seen.
+// Keys are compared case-insensitively like git does, lines without key are never duplicates.
+func isDuplicateTrailer(seen map[Trailer]bool, trailer Trailer) bool {
+	if trailer.Key == "" {
+		return false
+	}
+
+	normalized := Trailer{Key: strings.ToLower(trailer.Key), Value: trailer.Value}
+	if seen[normalized] {
+		return true
+	}
+	seen[normalized] = true
+
+	return false
+}
+
+// hasTrailer tells if the given trailers contain a trailer of the given key with a value.
+func hasTrailer(trailers []Trailer, key string) bool {
+	for _, trailer := range trailers {
+		if trailer.Key != "" && strings.EqualFold(trailer.Key, key) && trailer.Value != "" {
+			return true
+		}
+	}
+
+	return false
+}
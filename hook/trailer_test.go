@@ -0,0 +1,123 @@
+package hook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitCommitMessage(t *testing.T) {
+	testDataSet := map[string]struct {
+		commitMessage    string
+		expectedSubject  string
+		expectedBody     string
+		expectedTrailers []Trailer
+	}{
+		"empty message": {
+			commitMessage: "",
+		},
+		"subject only": {
+			commitMessage:   "add login form",
+			expectedSubject: "add login form",
+		},
+		"subject that looks like a trailer": {
+			commitMessage:   "fix: add login form",
+			expectedSubject: "fix: add login form",
+		},
+		"subject and body": {
+			commitMessage:   "add login form\n\nThe form validates the email.\n\nIt is shown on the start page.",
+			expectedSubject: "add login form",
+			expectedBody:    "The form validates the email.\n\nIt is shown on the start page.",
+		},
+		"subject and trailers": {
+			commitMessage:    "add login form\n\nRefs: PROJECT-123\nSigned-off-by: Jane Doe <jane@example.com>",
+			expectedSubject:  "add login form",
+			expectedTrailers: []Trailer{{Key: "Refs", Value: "PROJECT-123"}, {Key: "Signed-off-by", Value: "Jane Doe <jane@example.com>"}},
+		},
+		"subject, body and trailers": {
+			commitMessage:    "add login form\n\nThe form validates the email.\n\nRefs : PROJECT-123\nReviewed-by:Joe",
+			expectedSubject:  "add login form",
+			expectedBody:     "The form validates the email.",
+			expectedTrailers: []Trailer{{Key: "Refs", Value: "PROJECT-123"}, {Key: "Reviewed-by", Value: "Joe"}},
+		},
+		"continuation line": {
+			commitMessage:    "add login form\n\nNote: the form is\n  not styled yet\nRefs: PROJECT-123",
+			expectedSubject:  "add login form",
+			expectedTrailers: []Trailer{{Key: "Note", Value: "the form is not styled yet"}, {Key: "Refs", Value: "PROJECT-123"}},
+		},
+		"last paragraph with text is body": {
+			commitMessage:   "add login form\n\nRefs: PROJECT-123\nsee the ticket for details",
+			expectedSubject: "add login form",
+			expectedBody:    "Refs: PROJECT-123\nsee the ticket for details",
+		},
+		"key with spaces is no trailer": {
+			commitMessage:   "add login form\n\nSee also: PROJECT-123",
+			expectedSubject: "add login form",
+			expectedBody:    "See also: PROJECT-123",
+		},
+		"git generated lines allow other lines": {
+			commitMessage:   "add login form\n\nCc: Joe\nsee the ticket\nfor details\n(cherry picked from commit 0123abc)\nSigned-off-by: Jane Doe <jane@example.com>",
+			expectedSubject: "add login form",
+			expectedTrailers: []Trailer{
+				{Key: "Cc", Value: "Joe"},
+				{Value: "see the ticket"},
+				{Value: "for details"},
+				{Value: "(cherry picked from commit 0123abc)"},
+				{Key: "Signed-off-by", Value: "Jane Doe <jane@example.com>"},
+			},
+		},
+		"git generated lines need a quarter of trailers": {
+			commitMessage:   "add login form\n\none\ntwo\nthree\nfour\nSigned-off-by: Jane Doe <jane@example.com>\nfive",
+			expectedSubject: "add login form",
+			expectedBody:    "one\ntwo\nthree\nfour\nSigned-off-by: Jane Doe <jane@example.com>\nfive",
+		},
+		"multi line subject and blank lines": {
+			commitMessage:    "add login form\nand logout button\n\n\n\nbody\r\n\r\nRefs: PROJECT-123\n",
+			expectedSubject:  "add login form\nand logout button",
+			expectedBody:     "body",
+			expectedTrailers: []Trailer{{Key: "Refs", Value: "PROJECT-123"}},
+		},
+	}
+
+	for testName, testData := range testDataSet {
+		t.Run(testName, func(t *testing.T) {
+			subject, body, trailers := splitCommitMessage(testData.commitMessage)
+
+			assert.Exactly(t, testData.expectedSubject, subject)
+			assert.Exactly(t, testData.expectedBody, body)
+			assert.Exactly(t, testData.expectedTrailers, trailers)
+		})
+	}
+}
+
+func TestTrailer_String(t *testing.T) {
+	assert.Exactly(t, "Refs: PROJECT-123", Trailer{Key: "Refs", Value: "PROJECT-123"}.String())
+	assert.Exactly(t, "(cherry picked from commit 0123abc)", Trailer{Value: "(cherry picked from commit 0123abc)"}.String())
+}
+
+func TestSplitTrailerBlock(t *testing.T) {
+	head, trailers, lines := splitTrailerBlock("add login form\n\n\nbody\n\nRefs: PROJECT-1\r\nNote: the form is\r\n  not styled yet\r\n")
+	assert.Exactly(t, "add login form\n\n\nbody", head)
+	assert.Exactly(t, []Trailer{{Key: "Refs", Value: "PROJECT-1"}, {Key: "Note", Value: "the form is not styled yet"}}, trailers)
+	assert.Exactly(t, []string{"Refs: PROJECT-1", "Note: the form is\n  not styled yet"}, lines)
+
+	head, trailers, lines = splitTrailerBlock("Refs: PROJECT-1")
+	assert.Exactly(t, "Refs: PROJECT-1", head)
+	assert.Nil(t, trailers)
+	assert.Nil(t, lines)
+
+	head, trailers, lines = splitTrailerBlock("add login form\n\nbody")
+	assert.Exactly(t, "add login form\n\nbody", head)
+	assert.Nil(t, trailers)
+	assert.Nil(t, lines)
+}
+
+func TestIsDuplicateTrailer(t *testing.T) {
+	seen := map[Trailer]bool{}
+
+	assert.False(t, isDuplicateTrailer(seen, Trailer{Key: "Refs", Value: "PROJECT-1"}))
+	assert.True(t, isDuplicateTrailer(seen, Trailer{Key: "refs", Value: "PROJECT-1"}))
+	assert.False(t, isDuplicateTrailer(seen, Trailer{Key: "Refs", Value: "PROJECT-2"}))
+	assert.False(t, isDuplicateTrailer(seen, Trailer{Value: "(cherry picked from commit 0123abc)"}))
+	assert.False(t, isDuplicateTrailer(seen, Trailer{Value: "(cherry picked from commit 0123abc)"}))
+}
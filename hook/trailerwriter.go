@@ -0,0 +1,84 @@
+package hook
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/Oppodelldog/git-commit-hook/config"
+	"github.com/Oppodelldog/git-commit-hook/templatefuncs"
+)
+
+// CommitMessageTrailerWriter implements adding the configured trailers to a commit message
+type CommitMessageTrailerWriter interface {
+	WriteTrailers(viewModel ViewModel, commitMessage string) (string, error)
+}
+
+// NewCommitMessageTrailerWriter creates a new CommitMessageTrailerWriter
+func NewCommitMessageTrailerWriter(projConf config.Project) CommitMessageTrailerWriter {
+	return &commitMessageTrailerWriter{
+		projConf: projConf,
+	}
+}
+
+// commitMessageTrailerWriter adds the trailers configured for the branch type of the branch name to a commit message
+type commitMessageTrailerWriter struct {
+	projConf config.Project
+}
+
+// WriteTrailers appends the trailers configured for the branch type of the view model to the trailer block of the
+// given commit message and removes duplicate trailers. Like in Render, the branch type is taken from the view model
+// as the branch type resolver set it. The values of the trailers are rendered with the given view model, trailers
+// whose value renders empty are not added. Existing trailers keep their lines as written.
+// If no trailers are configured for the branch type or nothing changes, the commit message is returned unchanged.
+func (w *commitMessageTrailerWriter) WriteTrailers(viewModel ViewModel, commitMessage string) (string, error) {
+	trailerConfig, ok := w.projConf.GetTrailerConfiguration(viewModel.BranchType)
+	if !ok {
+		return commitMessage, nil
+	}
+
+	head, existingTrailers, existingLines := splitTrailerBlock(commitMessage)
+
+	var lines []string
+	var changed bool
+	seen := map[Trailer]bool{}
+	for i, trailer := range existingTrailers {
+		if isDuplicateTrailer(seen, trailer) {
+			changed = true
+			continue
+		}
+		lines = append(lines, existingLines[i])
+	}
+
+	for _, trailerTemplate := range trailerConfig.Add {
+		value, err := w.renderTrailerValue(trailerTemplate.Value, viewModel)
+		if err != nil {
+			return "", fmt.Errorf("trailer '%s': %v", trailerTemplate.Key, err)
+		}
+		trailer := Trailer{Key: trailerTemplate.Key, Value: value}
+		if value == "" || isDuplicateTrailer(seen, trailer) {
+			continue
+		}
+		lines = append(lines, trailer.String())
+		changed = true
+	}
+
+	if !changed {
+		return commitMessage, nil
+	}
+
+	return joinTrailerBlock(head, lines), nil
+}
+
+func (w *commitMessageTrailerWriter) renderTrailerValue(valueTemplate config.BranchTypeTemplate, viewModel ViewModel) (string, error) {
+	tmpl, err := template.New("trailerTemplate").Option("missingkey=zero").
+		Funcs(templatefuncs.FuncMap(w.projConf.RegexEngine)).
+		Parse(string(valueTemplate))
+	if err != nil {
+		return "", err
+	}
+
+	value, err := execute(tmpl, viewModel)
+
+	return strings.TrimSpace(value), err
+}
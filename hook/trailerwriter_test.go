@@ -0,0 +1,101 @@
+package hook
+
+import (
+	"testing"
+
+	"github.com/Oppodelldog/git-commit-hook/config"
+	"github.com/Oppodelldog/git-commit-hook/git"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteTrailers(t *testing.T) {
+	cfg := config.Project{
+		BranchTypes: config.BranchTypes{
+			{Name: "feature", Pattern: `^feature/(?P<ticket>[A-Z]+-[0-9]+)?`},
+		},
+		Trailers: map[string]config.TrailerConfiguration{
+			"feature": {Add: []config.TrailerTemplate{
+				{Key: "Ticket", Value: "{{.Branch.ticket}}"},
+				{Key: "Signed-off-by", Value: "{{.AuthorName}} <{{.AuthorEmail}}>"},
+			}},
+		},
+	}
+	viewModel := ViewModel{repository: &repositoryState{accessors: gitAccessors{
+		getAuthor: func() (git.Author, error) { return git.Author{Name: "Jane Doe", Email: "jane@example.com"}, nil },
+	}}}
+
+	testDataSet := map[string]struct {
+		branchName            string
+		commitMessage         string
+		expectedCommitMessage string
+	}{
+		"adds trailers": {
+			branchName:            "feature/PROJECT-1",
+			commitMessage:         "add login form",
+			expectedCommitMessage: "add login form\n\nTicket: PROJECT-1\nSigned-off-by: Jane Doe <jane@example.com>",
+		},
+		"appends to existing trailer block": {
+			branchName:            "feature/PROJECT-1",
+			commitMessage:         "add login form\n\nbody\n\nCo-authored-by: Joe <joe@example.com>\n",
+			expectedCommitMessage: "add login form\n\nbody\n\nCo-authored-by: Joe <joe@example.com>\nTicket: PROJECT-1\nSigned-off-by: Jane Doe <jane@example.com>",
+		},
+		"adds only missing trailers": {
+			branchName:            "feature/PROJECT-1",
+			commitMessage:         "add login form\n\nsigned-off-by: Jane Doe <jane@example.com>\nTicket: PROJECT-1",
+			expectedCommitMessage: "add login form\n\nsigned-off-by: Jane Doe <jane@example.com>\nTicket: PROJECT-1",
+		},
+		"adds trailer of a different value": {
+			branchName:            "feature/PROJECT-1",
+			commitMessage:         "add login form\n\nTicket: PROJECT-2\nSigned-off-by: Jane Doe <jane@example.com>",
+			expectedCommitMessage: "add login form\n\nTicket: PROJECT-2\nSigned-off-by: Jane Doe <jane@example.com>\nTicket: PROJECT-1",
+		},
+		"drops duplicates": {
+			branchName:            "feature/PROJECT-1",
+			commitMessage:         "add login form\n\nRefs: PROJECT-1\nRefs: PROJECT-2\nrefs: PROJECT-1\nTicket: PROJECT-1",
+			expectedCommitMessage: "add login form\n\nRefs: PROJECT-1\nRefs: PROJECT-2\nTicket: PROJECT-1\nSigned-off-by: Jane Doe <jane@example.com>",
+		},
+		"keeps lines of existing trailers": {
+			branchName:            "feature/PROJECT-1",
+			commitMessage:         "add login form\n\nReviewed-by: A\n    B\nRefs : PROJECT-2",
+			expectedCommitMessage: "add login form\n\nReviewed-by: A\n    B\nRefs : PROJECT-2\nTicket: PROJECT-1\nSigned-off-by: Jane Doe <jane@example.com>",
+		},
+		"leaves message untouched without trailer configuration": {
+			branchName:            "develop",
+			commitMessage:         "msg\n\nReviewed-by: A\n    B\nAcked-by: X\nacked-by: X",
+			expectedCommitMessage: "msg\n\nReviewed-by: A\n    B\nAcked-by: X\nacked-by: X",
+		},
+		"skips empty values": {
+			branchName:            "feature/login",
+			commitMessage:         "add login form",
+			expectedCommitMessage: "add login form\n\nSigned-off-by: Jane Doe <jane@example.com>",
+		},
+		"keeps message without changes untouched": {
+			branchName:            "feature/PROJECT-1",
+			commitMessage:         "add login form\n\nTicket : PROJECT-1\nSigned-off-by: Jane Doe <jane@example.com>\n",
+			expectedCommitMessage: "add login form\n\nTicket : PROJECT-1\nSigned-off-by: Jane Doe <jane@example.com>\n",
+		},
+	}
+
+	for testName, testData := range testDataSet {
+		t.Run(testName, func(t *testing.T) {
+			viewModel.BranchName = testData.branchName
+
+			commitMessage, err := NewCommitMessageTrailerWriter(cfg).WriteTrailers(resolveBranchType(t, cfg, viewModel), testData.commitMessage)
+
+			assert.NoError(t, err)
+			assert.Exactly(t, testData.expectedCommitMessage, commitMessage)
+		})
+	}
+}
+
+func TestWriteTrailers_InvalidTemplate_ReturnsError(t *testing.T) {
+	cfg := config.Project{
+		Trailers: map[string]config.TrailerConfiguration{
+			"*": {Add: []config.TrailerTemplate{{Key: "Ticket", Value: "{{.Branch.ticket}"}}},
+		},
+	}
+
+	_, err := NewCommitMessageTrailerWriter(cfg).WriteTrailers(ViewModel{}, "add login form")
+
+	assert.EqualError(t, err, "trailer 'Ticket': template: trailerTemplate:1: bad character U+007D '}'")
+}
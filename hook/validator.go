@@ -16,46 +16,139 @@ type (
 	}
 
 	commitMessageValidator struct {
-		projectConfig config.Project
+		projectConfig         config.Project
+		resolveBranchTypeFunc resolveBranchTypeFuncDef
 	}
 )
 
 // NewCommitMessageValidator creates a new CommitMessageValidator
 func NewCommitMessageValidator(projectConfig config.Project) CommitMessageValidator {
+	return newCommitMessageValidator(projectConfig)
+}
+
+func newCommitMessageValidator(projectConfig config.Project) *commitMessageValidator {
 	return &commitMessageValidator{
-		projectConfig: projectConfig,
+		projectConfig:         projectConfig,
+		resolveBranchTypeFunc: newBranchTypeResolver(projectConfig),
 	}
 }
 
-// Validate validates the given commitMessage. It uses the validations configured for the given branchName.
+// Validate validates the given commitMessage. It uses the validations configured for the branch type of the given
+// branchName, which is resolved like for rendering the commit message.
 // As soon as one validation check succeeds, the validation passes.
+// Additionally the validation rule configured for the branch type must pass, every failed rule is reported.
+// If conventional commits are configured for the branch type, the commit message must follow that specification.
+// Every trailer required for the branch type must be in the trailer block of the commit message.
 func (v *commitMessageValidator) Validate(branchName, commitMessage string) error {
+	viewModel, err := v.resolveBranchTypeFunc(ViewModel{BranchName: branchName})
+	if err != nil {
+		return err
+	}
+
+	return v.validate(viewModel, commitMessage)
+}
 
-	branchType := v.projectConfig.GetBranchType(branchName)
+// validate works like Validate, but takes the branch type of the given view model, which the branch type resolver
+// completed before. So the commit message is validated by the branch type it was rendered with.
+func (v *commitMessageValidator) validate(viewModel ViewModel, commitMessage string) error {
+	branchName, branchType := viewModel.BranchName, viewModel.BranchType
 	validators := v.projectConfig.GetValidator(branchType)
-	if len(validators) == 0 {
+
+	var failedValidators map[string]string
+	if len(validators) > 0 {
+		matches, err := anyValidatorMatches(validators, commitMessage, v.projectConfig.RegexEngine)
+		if err != nil {
+			return err
+		}
+		if !matches {
+			failedValidators = validators
+		}
+	}
+
+	var failedRules []string
+	if rule, ok := v.projectConfig.GetValidationRule(branchType); ok {
+		var err error
+		failedRules, err = evaluateRule(rule, commitMessage, v.projectConfig.RegexEngine)
+		if err != nil {
+			return err
+		}
+	}
+
+	var conventionalCommitErrors []string
+	if conventionalConfig, ok := v.projectConfig.GetConventionalCommitConfiguration(branchType); ok {
+		conventionalCommitErrors = validateConventionalCommit(conventionalConfig, commitMessage)
+	}
+
+	var missingTrailers []string
+	if trailerConfig, ok := v.projectConfig.GetTrailerConfiguration(branchType); ok {
+		missingTrailers = getMissingTrailers(trailerConfig.Require, commitMessage)
+	}
+
+	if len(failedValidators) == 0 && len(failedRules) == 0 && len(conventionalCommitErrors) == 0 && len(missingTrailers) == 0 {
 		return nil
 	}
 
+	return prepareError(branchName, failedValidators, failedRules, conventionalCommitErrors, missingTrailers)
+}
+
+func getMissingTrailers(requiredKeys []string, commitMessage string) []string {
+	_, trailers, _ := splitTrailerBlock(commitMessage)
+
+	var missingTrailers []string
+	for _, key := range requiredKeys {
+		if !hasTrailer(trailers, key) {
+			missingTrailers = append(missingTrailers, key)
+		}
+	}
+
+	return missingTrailers
+}
+
+func anyValidatorMatches(validators map[string]string, commitMessage string, regexEngine string) (bool, error) {
 	for validationPattern := range validators {
-		if regexadapter.RegexMatchesString(validationPattern, commitMessage) {
-			return nil
+		matches, err := regexadapter.RegexMatchesString(regexEngine, validationPattern, commitMessage)
+		if err != nil {
+			return false, err
+		}
+		if matches {
+			return true, nil
 		}
 	}
 
-	return prepareError(branchName, validators)
+	return false, nil
 }
 
-func prepareError(branchName string, validators map[string]string) error {
+func prepareError(branchName string, validators map[string]string, failedRules, conventionalCommitErrors, missingTrailers []string) error {
 	buffer := bytes.NewBufferString("validation error for branch ")
 	buffer.WriteString(fmt.Sprintf("'%s'\n", branchName))
-	buffer.WriteString("at least expected one of the following to match\n")
 
-	for _, validationDescription := range validators {
-		buffer.WriteString(" - ")
-		buffer.WriteString(validationDescription)
-		buffer.WriteString("\n")
+	if len(validators) > 0 {
+		buffer.WriteString("at least expected one of the following to match\n")
+
+		for _, validationDescription := range validators {
+			buffer.WriteString(" - ")
+			buffer.WriteString(validationDescription)
+			buffer.WriteString("\n")
+		}
 	}
 
+	writeErrorList(buffer, "the following rules failed\n", failedRules)
+	writeErrorList(buffer, "not a valid conventional commit\n", conventionalCommitErrors)
+	writeErrorList(buffer, "the following trailers are missing\n", missingTrailers)
+
 	return errors.New(buffer.String())
 }
+
+func writeErrorList(buffer *bytes.Buffer, headline string, errs []string) {
+	if len(errs) == 0 {
+		return
+	}
+
+	buffer.WriteString(headline)
+
+	for _, err := range errs {
+		buffer.WriteString(" - ")
+		buffer.WriteString(err)
+		buffer.WriteString("\n")
+	}
+}
@@ -1,6 +1,7 @@
 package hook
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/Oppodelldog/git-commit-hook/config"
@@ -52,13 +53,13 @@ func TestValidate(t *testing.T) {
 	}
 
 	for testName, testData := range testDataSet {
-		t.Run(string(testName), func(t *testing.T) {
+		t.Run(strconv.Itoa(testName), func(t *testing.T) {
 
 			cfg := config.Project{
-				BranchTypes: map[string]config.BranchTypePattern{
-					"branch1": `^branch1$`,
-					"branch2": `^branch2$`,
-					"branch3": `^branch3$`,
+				BranchTypes: config.BranchTypes{
+					{Name: "branch1", Pattern: `^branch1$`},
+					{Name: "branch2", Pattern: `^branch2$`},
+					{Name: "branch3", Pattern: `^branch3$`},
 				},
 				Validation: map[string]config.BranchValidationConfiguration{
 					"branch1": map[string]string{
@@ -85,3 +86,156 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate_Rules(t *testing.T) {
+	cfg := config.Project{
+		BranchTypes: config.BranchTypes{
+			{Name: "release", Pattern: `^release/.*$`},
+		},
+		Rules: map[string]config.ValidationRule{
+			"release": {
+				AllOf: []config.ValidationRule{
+					{Pattern: `[A-Z]+-[0-9]+`, Description: "contain a ticket ID"},
+					{SubjectMaxLength: 72},
+				},
+				NoneOf: []config.ValidationRule{
+					{Pattern: `WIP`, Description: "not contain WIP"},
+				},
+			},
+		},
+	}
+
+	validator := NewCommitMessageValidator(cfg)
+
+	assert.NoError(t, validator.Validate("release/v1.0.0", "PROJECT-1 fixed"))
+
+	err := validator.Validate("release/v1.0.0", "WIP fixed")
+	expectedError := "validation error for branch 'release/v1.0.0'\n" +
+		"the following rules failed\n" +
+		" - contain a ticket ID\n" +
+		" - not contain WIP\n"
+	assert.EqualError(t, err, expectedError)
+}
+
+func TestValidate_RegexEngine(t *testing.T) {
+	cfg := config.Project{
+		RegexEngine: "pcre",
+		Validation: map[string]config.BranchValidationConfiguration{
+			"*": {`^(?!.*WIP).*[A-Z]+-[0-9]+`: "a ticket ID without WIP"},
+		},
+		Rules: map[string]config.ValidationRule{
+			"*": {Pattern: `re2:^\S`, Description: "start with a word"},
+		},
+	}
+
+	validator := NewCommitMessageValidator(cfg)
+
+	assert.NoError(t, validator.Validate("develop", "PROJECT-1 fixed"))
+
+	err := validator.Validate("develop", " WIP PROJECT-1")
+	expectedError := "validation error for branch 'develop'\n" +
+		"at least expected one of the following to match\n" +
+		" - a ticket ID without WIP\n" +
+		"the following rules failed\n" +
+		" - start with a word\n"
+	assert.EqualError(t, err, expectedError)
+}
+
+func TestValidate_RulesAndValidatorsFail_BothAreReported(t *testing.T) {
+	cfg := config.Project{
+		Validation: map[string]config.BranchValidationConfiguration{
+			"*": {"@noissue": "@noissue"},
+		},
+		Rules: map[string]config.ValidationRule{
+			"*": {NoneOf: []config.ValidationRule{{Pattern: `WIP`, Description: "not contain WIP"}}},
+		},
+	}
+
+	err := NewCommitMessageValidator(cfg).Validate("develop", "WIP")
+
+	expectedError := "validation error for branch 'develop'\n" +
+		"at least expected one of the following to match\n" +
+		" - @noissue\n" +
+		"the following rules failed\n" +
+		" - not contain WIP\n"
+	assert.EqualError(t, err, expectedError)
+}
+
+func TestValidate_InvalidPatterns_ReturnError(t *testing.T) {
+	testDataSet := map[string]struct {
+		cfg           config.Project
+		expectedError string
+	}{
+		"branch type pattern": {
+			cfg: config.Project{
+				BranchTypes: config.BranchTypes{{Name: "feature", Pattern: `^feature/(.*$`}},
+			},
+			expectedError: "branch type 'feature': invalid pattern '^feature/(.*$': error parsing regexp: missing closing ): `^feature/(.*$`",
+		},
+		"validation pattern": {
+			cfg: config.Project{
+				Validation: map[string]config.BranchValidationConfiguration{"*": {"(PROJECT-[0-9]+": "ticket ID"}},
+			},
+			expectedError: "invalid pattern '(PROJECT-[0-9]+': error parsing regexp: missing closing ): `(PROJECT-[0-9]+`",
+		},
+		"rule pattern": {
+			cfg: config.Project{
+				Rules: map[string]config.ValidationRule{"*": {Pattern: "[a-z"}},
+			},
+			expectedError: "invalid pattern '[a-z': error parsing regexp: missing closing ]: `[a-z`",
+		},
+	}
+
+	for testCaseName, testData := range testDataSet {
+		t.Run(testCaseName, func(t *testing.T) {
+			err := NewCommitMessageValidator(testData.cfg).Validate("feature/PROJECT-123", "PROJECT-123 commit message")
+
+			assert.EqualError(t, err, testData.expectedError)
+		})
+	}
+}
+
+func TestValidate_ConventionalCommit(t *testing.T) {
+	cfg := config.Project{
+		Conventional: map[string]config.ConventionalCommitConfiguration{
+			"*": {Types: []string{"feat", "fix"}},
+		},
+	}
+
+	validator := NewCommitMessageValidator(cfg)
+
+	assert.NoError(t, validator.Validate("develop", "feat: add login"))
+
+	err := validator.Validate("develop", "chore: update deps")
+	expectedError := "validation error for branch 'develop'\n" +
+		"not a valid conventional commit\n" +
+		" - type 'chore' is not allowed, allowed types: feat, fix\n"
+	assert.EqualError(t, err, expectedError)
+}
+
+func TestValidate_RequiredTrailers(t *testing.T) {
+	cfg := config.Project{
+		BranchTypes: config.BranchTypes{{Name: "release", Pattern: "^release/.*$"}},
+		Trailers: map[string]config.TrailerConfiguration{
+			"release": {Require: []string{"Refs", "Reviewed-by"}},
+		},
+	}
+
+	validator := NewCommitMessageValidator(cfg)
+
+	assert.NoError(t, validator.Validate("develop", "fix login"))
+	assert.NoError(t, validator.Validate("release/v1.0.0", "fix login\n\nrefs: PROJECT-1\nReviewed-by: Joe"))
+
+	err := validator.Validate("release/v1.0.0", "fix login\n\nRefs: PROJECT-1\n\nReviewed-by: Joe")
+	expectedError := "validation error for branch 'release/v1.0.0'\n" +
+		"the following trailers are missing\n" +
+		" - Refs\n"
+	assert.EqualError(t, err, expectedError)
+
+	err = validator.Validate("release/v1.0.0", "Reviewed-by: Joe")
+	expectedError = "validation error for branch 'release/v1.0.0'\n" +
+		"the following trailers are missing\n" +
+		" - Refs\n" +
+		" - Reviewed-by\n"
+	assert.EqualError(t, err, expectedError)
+}
@@ -1,23 +1,248 @@
 package hook
 
 import (
+	"os"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/Oppodelldog/git-commit-hook/config"
+	"github.com/Oppodelldog/git-commit-hook/git"
 )
 
-// ViewModel defines all variables that can be in templates to define the modified commit message
+// ViewModel defines all variables that can be in templates to define the modified commit message.
+// The values of the repository, like AuthorName or StagedFiles, are methods that run git when a template refers to
+// them the first time, so templates that do not use them run no git commands.
 type (
 	ViewModel struct {
 		BranchName    string
 		CommitMessage string
+		// Subject is the first paragraph of the commit message, usually its first line
+		Subject string
+		// Body is the commit message without subject and trailers
+		Body string
+		// Trailers are the key value pairs of the last paragraph of the commit message, like 'Refs: PROJECT-123'
+		Trailers []Trailer
+		// Branch holds the named capture groups of the branch type pattern that matched the branch name
+		Branch map[string]string
+		// BranchType is the branch type the branch name matched, it is empty if no branch type matched
+		BranchType string
+		// ProjectName is the name of the configured project the commit belongs to
+		ProjectName string
+
+		repository *repositoryState
+	}
+
+	// repositoryState loads the values of the repository by the git accessors it was created with, each of them
+	// at most once. A nil repositoryState has no values.
+	repositoryState struct {
+		accessors gitAccessors
+
+		headOnce  sync.Once
+		commitSHA string
+
+		authorOnce sync.Once
+		author     git.Author
+		hasAuthor  bool
+
+		repositoryNameOnce sync.Once
+		repositoryName     string
+
+		upstreamOnce sync.Once
+		upstream     string
+
+		stagedFilesOnce sync.Once
+		stagedFiles     []string
+	}
+
+	// gitAccessors read the state of the repository the commit is made in
+	gitAccessors struct {
+		getAuthor             getAuthorFuncDef
+		getHead               getHeadFuncDef
+		getRepositoryName     getRepositoryNameFuncDef
+		getUpstreamBranchName getUpstreamBranchNameFuncDef
+		getStagedFiles        getStagedFilesFuncDef
+		now                   nowFuncDef
 	}
 )
 
+type (
+	getAuthorFuncDef             func() (git.Author, error)
+	getHeadFuncDef               func() (git.Head, error)
+	getRepositoryNameFuncDef     func() string
+	getUpstreamBranchNameFuncDef func() string
+	getStagedFilesFuncDef        func() []string
+	nowFuncDef                   func() time.Time
+)
+
+var (
+	getAuthorFunc             = getAuthorFuncDef(git.GetAuthor)
+	getHeadFunc               = getHeadFuncDef(git.GetHead)
+	getRepositoryNameFunc     = getRepositoryNameFuncDef(getRepositoryName)
+	getUpstreamBranchNameFunc = getUpstreamBranchNameFuncDef(git.GetUpstreamBranchName)
+	getStagedFilesFunc        = getStagedFilesFuncDef(git.GetStagedFiles)
+	nowFunc                   = nowFuncDef(time.Now)
+)
+
 func createViewModel(commitMessage string, branchName string) ViewModel {
 	trimmedCommitMessage := strings.Trim(commitMessage, " \t\r\n")
 	viewModel := ViewModel{
 		CommitMessage: trimmedCommitMessage,
 		BranchName:    branchName,
+		repository: &repositoryState{accessors: gitAccessors{
+			getAuthor:             getAuthorFunc,
+			getHead:               getHeadFunc,
+			getRepositoryName:     getRepositoryNameFunc,
+			getUpstreamBranchName: getUpstreamBranchNameFunc,
+			getStagedFiles:        getStagedFilesFunc,
+			now:                   nowFunc,
+		}},
 	}
+	viewModel.Subject, viewModel.Body, viewModel.Trailers = splitCommitMessage(trimmedCommitMessage)
 
 	return viewModel
 }
+
+// CommitSHA is the commit HEAD points to, it identifies a detached HEAD whose BranchName is empty
+func (v ViewModel) CommitSHA() string {
+	if v.repository == nil {
+		return ""
+	}
+	v.repository.headOnce.Do(func() {
+		if head, err := v.repository.accessors.getHead(); err == nil {
+			v.repository.commitSHA = head.CommitSHA
+		}
+	})
+
+	return v.repository.commitSHA
+}
+
+// RepositoryName is the name of the repository, which is the name of the directory of the main worktree
+func (v ViewModel) RepositoryName() string {
+	if v.repository == nil {
+		return ""
+	}
+	v.repository.repositoryNameOnce.Do(func() {
+		v.repository.repositoryName = v.repository.accessors.getRepositoryName()
+	})
+
+	return v.repository.repositoryName
+}
+
+// Upstream is the upstream of the current branch, like 'origin/develop', it is empty if none is set
+func (v ViewModel) Upstream() string {
+	if v.repository == nil {
+		return ""
+	}
+	v.repository.upstreamOnce.Do(func() {
+		v.repository.upstream = v.repository.accessors.getUpstreamBranchName()
+	})
+
+	return v.repository.upstream
+}
+
+// AuthorName is the name of the author of the commit
+func (v ViewModel) AuthorName() string {
+	author, _ := v.getAuthor()
+
+	return author.Name
+}
+
+// AuthorEmail is the email of the author of the commit
+func (v ViewModel) AuthorEmail() string {
+	author, _ := v.getAuthor()
+
+	return author.Email
+}
+
+// Date is the author date of the commit, templates may format it like {{.Date.Format "2006-01-02"}}.
+// If the author is unknown, it is the current time.
+func (v ViewModel) Date() time.Time {
+	if author, ok := v.getAuthor(); ok {
+		return author.Date
+	}
+	if v.repository == nil {
+		return time.Time{}
+	}
+
+	return v.repository.accessors.now()
+}
+
+// StagedFiles lists the paths of the staged files relative to the worktree root
+func (v ViewModel) StagedFiles() []string {
+	if v.repository == nil {
+		return nil
+	}
+	v.repository.stagedFilesOnce.Do(func() {
+		v.repository.stagedFiles = v.repository.accessors.getStagedFiles()
+	})
+
+	return v.repository.stagedFiles
+}
+
+// StagedDirs lists the top-level directories of the staged files, files in the worktree root have none
+func (v ViewModel) StagedDirs() []string {
+	return getTopLevelDirs(v.StagedFiles())
+}
+
+func (v ViewModel) getAuthor() (git.Author, bool) {
+	if v.repository == nil {
+		return git.Author{}, false
+	}
+	v.repository.authorOnce.Do(func() {
+		author, err := v.repository.accessors.getAuthor()
+		v.repository.author, v.repository.hasAuthor = author, err == nil
+	})
+
+	return v.repository.author, v.repository.hasAuthor
+}
+
+// newBranchTypeResolver returns a function that completes a view model by the branch type its branch name matches in
+// the given project configuration, the named capture groups of the branch type pattern and the project name.
+// Branch types are matched once per commit message, so renderer and trailer writer get the same result.
+func newBranchTypeResolver(projectConfiguration config.Project) resolveBranchTypeFuncDef {
+	return func(viewModel ViewModel) (ViewModel, error) {
+		branchType, branchGroups, err := projectConfiguration.GetBranchTypeMatch(viewModel.BranchName)
+		if err != nil {
+			return viewModel, err
+		}
+		viewModel.Branch = branchGroups
+		viewModel.BranchType = branchType
+		viewModel.ProjectName = projectConfiguration.Name
+
+		return viewModel, nil
+	}
+}
+
+func getRepositoryName() string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+
+	gitDir, err := git.FindGitDir(wd)
+	if err != nil {
+		return ""
+	}
+
+	return git.GetRepositoryName(gitDir)
+}
+
+// getTopLevelDirs returns the distinct top-level directories of the given paths in the order they first appear.
+func getTopLevelDirs(paths []string) []string {
+	var dirs []string
+	seen := map[string]bool{}
+	for _, path := range paths {
+		i := strings.Index(path, "/")
+		if i <= 0 {
+			continue
+		}
+		dir := path[:i]
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+
+	return dirs
+}
@@ -1,19 +1,205 @@
 package hook
 
 import (
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/Oppodelldog/git-commit-hook/config"
+	"github.com/Oppodelldog/git-commit-hook/git"
 	"github.com/stretchr/testify/assert"
 )
 
+var testDate = time.Date(2023, 11, 14, 23, 13, 20, 0, time.UTC)
+
 func TestCreateViewModel_BranchName(t *testing.T) {
+	defer restoreViewModelOriginals()
+	withoutRepositoryState()
+
 	commitMessage := "\n\r\n\t\tHELLO\n\tWORLD\t\r\n\r\t"
 	branchName := "456"
 	viewModel := createViewModel(commitMessage, branchName)
 
-	expectedViewModel := ViewModel{
-		CommitMessage: "HELLO\n\tWORLD",
-		BranchName:    branchName,
+	assert.Exactly(t, "HELLO\n\tWORLD", viewModel.CommitMessage)
+	assert.Exactly(t, "HELLO\n\tWORLD", viewModel.Subject)
+	assert.Exactly(t, "", viewModel.Body)
+	assert.Nil(t, viewModel.Trailers)
+	assert.Exactly(t, branchName, viewModel.BranchName)
+	assert.Exactly(t, "", viewModel.CommitSHA())
+	assert.Exactly(t, "", viewModel.AuthorName())
+	assert.Exactly(t, testDate, viewModel.Date())
+	assert.Nil(t, viewModel.StagedDirs())
+}
+
+func TestCreateViewModel_RepositoryState(t *testing.T) {
+	defer restoreViewModelOriginals()
+	getAuthorFunc = func() (git.Author, error) {
+		return git.Author{Name: "Jane Doe", Email: "jane@example.com", Date: testDate}, nil
+	}
+	getHeadFunc = func() (git.Head, error) { return git.Head{BranchName: "feature/PROJ-12", CommitSHA: "0123abc"}, nil }
+	getRepositoryNameFunc = func() string { return "platform" }
+	getUpstreamBranchNameFunc = func() string { return "origin/feature/PROJ-12" }
+	getStagedFilesFunc = func() []string {
+		return []string{"svc-auth/main.go", "README.md", "svc-auth/api/user.go", "lib/log.go"}
+	}
+	nowFunc = func() time.Time { return time.Time{} }
+
+	viewModel := createViewModel("msg", "feature/PROJ-12")
+
+	assert.Exactly(t, "0123abc", viewModel.CommitSHA())
+	assert.Exactly(t, "platform", viewModel.RepositoryName())
+	assert.Exactly(t, "origin/feature/PROJ-12", viewModel.Upstream())
+	assert.Exactly(t, "Jane Doe", viewModel.AuthorName())
+	assert.Exactly(t, "jane@example.com", viewModel.AuthorEmail())
+	assert.Exactly(t, testDate, viewModel.Date())
+	assert.Exactly(t, []string{"svc-auth/main.go", "README.md", "svc-auth/api/user.go", "lib/log.go"}, viewModel.StagedFiles())
+	assert.Exactly(t, []string{"svc-auth", "lib"}, viewModel.StagedDirs())
+}
+
+func TestCreateViewModel_LoadsRepositoryStateWhenUsed(t *testing.T) {
+	defer restoreViewModelOriginals()
+	calls := map[string]int{}
+	getAuthorFunc = func() (git.Author, error) {
+		calls["author"]++
+		return git.Author{Name: "Jane Doe", Email: "jane@example.com", Date: testDate}, nil
+	}
+	getHeadFunc = func() (git.Head, error) { calls["head"]++; return git.Head{}, nil }
+	getRepositoryNameFunc = func() string { calls["repositoryName"]++; return "" }
+	getUpstreamBranchNameFunc = func() string { calls["upstream"]++; return "" }
+	getStagedFilesFunc = func() []string { calls["stagedFiles"]++; return nil }
+	nowFunc = func() time.Time { calls["now"]++; return time.Time{} }
+	projectCfg := config.Project{
+		BranchTypes: config.BranchTypes{{Name: "feature", Pattern: "^feature/.*$"}},
+		Templates:   map[string]config.BranchTypeTemplate{"feature": "{{.CommitMessage}}\n\n{{.AuthorName}} <{{.AuthorEmail}}>"},
+	}
+
+	viewModel := resolveBranchType(t, projectCfg, createViewModel("msg", "feature/PROJ-12"))
+	assert.Empty(t, calls)
+
+	message, err := NewCommitMessageRenderer(projectCfg).Render(viewModel)
+
+	assert.NoError(t, err)
+	assert.Exactly(t, "msg\n\nJane Doe <jane@example.com>", message)
+	assert.Exactly(t, map[string]int{"author": 1}, calls)
+}
+
+func TestRender_RepositoryStateInTemplate(t *testing.T) {
+	defer restoreViewModelOriginals()
+	withoutRepositoryState()
+	getStagedFilesFunc = func() []string { return []string{"svc-auth/main.go"} }
+	projectCfg := config.Project{
+		Name:        "acme",
+		BranchTypes: config.BranchTypes{{Name: "feature", Pattern: "^feature/(?P<ticket>[A-Z]+-[0-9]+)"}},
+		Templates: map[string]config.BranchTypeTemplate{
+			"feature": `[{{index .StagedDirs 0}}] {{.Branch.ticket}}: {{.CommitMessage}} ({{.ProjectName}}, {{.BranchType}}, {{.Date.Format "2006-01-02"}})`,
+		},
+	}
+
+	message, err := NewCommitMessageRenderer(projectCfg).Render(resolveBranchType(t, projectCfg, createViewModel("msg", "feature/PROJ-12")))
+
+	assert.NoError(t, err)
+	assert.Exactly(t, "[svc-auth] PROJ-12: msg (acme, feature, 2023-11-14)", message)
+}
+
+func TestRender_GuardedStagedDirsInTemplate(t *testing.T) {
+	defer restoreViewModelOriginals()
+	withoutRepositoryState()
+	projectCfg := config.Project{
+		BranchTypes: config.BranchTypes{{Name: "feature", Pattern: "^feature/(?P<ticket>[A-Z]+-[0-9]+)"}},
+		Templates: map[string]config.BranchTypeTemplate{
+			"feature": "{{with .StagedDirs}}[{{index . 0}}] {{end}}{{.Branch.ticket}}: {{.CommitMessage}}",
+		},
+	}
+	renderer := NewCommitMessageRenderer(projectCfg)
+
+	getStagedFilesFunc = func() []string { return []string{"svc-auth/login.go"} }
+	message, err := renderer.Render(resolveBranchType(t, projectCfg, createViewModel("msg", "feature/PROJ-12-login")))
+	assert.NoError(t, err)
+	assert.Exactly(t, "[svc-auth] PROJ-12: msg", message)
+
+	getStagedFilesFunc = func() []string { return []string{"README.md"} }
+	message, err = renderer.Render(resolveBranchType(t, projectCfg, createViewModel("msg", "feature/PROJ-12-login")))
+	assert.NoError(t, err)
+	assert.Exactly(t, "PROJ-12: msg", message)
+}
+
+func TestBranchTypeResolver(t *testing.T) {
+	projectCfg := config.Project{
+		Name:        "acme",
+		BranchTypes: config.BranchTypes{{Name: "feature", Pattern: "^feature/(?P<ticket>[A-Z]+-[0-9]+)"}},
+	}
+
+	viewModel, err := newBranchTypeResolver(projectCfg)(ViewModel{BranchName: "feature/PROJ-12", CommitMessage: "msg"})
+
+	assert.NoError(t, err)
+	assert.Exactly(t, ViewModel{
+		BranchName:    "feature/PROJ-12",
+		CommitMessage: "msg",
+		Branch:        map[string]string{"ticket": "PROJ-12"},
+		BranchType:    "feature",
+		ProjectName:   "acme",
+	}, viewModel)
+}
+
+func TestBranchTypeResolver_InvalidBranchTypePattern_ReturnsError(t *testing.T) {
+	projectCfg := config.Project{
+		BranchTypes: config.BranchTypes{
+			{Name: "feature", Pattern: `^feature/(?P<ticket>.*$`},
+		},
 	}
-	assert.Exactly(t, expectedViewModel, viewModel)
+
+	_, err := newBranchTypeResolver(projectCfg)(ViewModel{BranchName: "feature/PROJECT-123", CommitMessage: "commit message"})
+
+	assert.EqualError(t, err, "branch type 'feature': invalid pattern '^feature/(?P<ticket>.*$': error parsing regexp: missing closing ): `^feature/(?P<ticket>.*$`")
+}
+
+func TestGetTopLevelDirs(t *testing.T) {
+	assert.Nil(t, getTopLevelDirs(nil))
+	assert.Nil(t, getTopLevelDirs([]string{"README.md"}))
+	assert.Exactly(t, []string{"b", "a"}, getTopLevelDirs([]string{"b/x", "a/y/z", "b/y", "c"}))
+}
+
+// resolveBranchType completes the given view model by the branch type its branch name matches in the given project
+// configuration, like the modifier does before rendering.
+func resolveBranchType(t *testing.T, projectCfg config.Project, viewModel ViewModel) ViewModel {
+	viewModel, err := newBranchTypeResolver(projectCfg)(viewModel)
+	if err != nil {
+		t.Fatalf("Did not expect the branch type resolver to return an error, but got: %v ", err)
+	}
+
+	return viewModel
+}
+
+func withoutRepositoryState() {
+	getAuthorFunc = func() (git.Author, error) { return git.Author{}, errors.New("no author") }
+	getHeadFunc = func() (git.Head, error) { return git.Head{}, errors.New("no head") }
+	getRepositoryNameFunc = func() string { return "" }
+	getUpstreamBranchNameFunc = func() string { return "" }
+	getStagedFilesFunc = func() []string { return nil }
+	nowFunc = func() time.Time { return testDate }
+}
+
+var viewModelOriginals = struct {
+	getAuthorFunc             getAuthorFuncDef
+	getHeadFunc               getHeadFuncDef
+	getRepositoryNameFunc     getRepositoryNameFuncDef
+	getUpstreamBranchNameFunc getUpstreamBranchNameFuncDef
+	getStagedFilesFunc        getStagedFilesFuncDef
+	nowFunc                   nowFuncDef
+}{
+	getAuthorFunc:             getAuthorFunc,
+	getHeadFunc:               getHeadFunc,
+	getRepositoryNameFunc:     getRepositoryNameFunc,
+	getUpstreamBranchNameFunc: getUpstreamBranchNameFunc,
+	getStagedFilesFunc:        getStagedFilesFunc,
+	nowFunc:                   nowFunc,
+}
+
+func restoreViewModelOriginals() {
+	getAuthorFunc = viewModelOriginals.getAuthorFunc
+	getHeadFunc = viewModelOriginals.getHeadFunc
+	getRepositoryNameFunc = viewModelOriginals.getRepositoryNameFunc
+	getUpstreamBranchNameFunc = viewModelOriginals.getUpstreamBranchNameFunc
+	getStagedFilesFunc = viewModelOriginals.getStagedFilesFunc
+	nowFunc = viewModelOriginals.nowFunc
 }
@@ -0,0 +1,63 @@
+package regexadapter
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/dlclark/regexp2"
+)
+
+// pcreMatchTimeout stops patterns that backtrack catastrophically from blocking a commit
+const pcreMatchTimeout = time.Second
+
+// pcreEngine is a pure go backtracking engine, so it builds without cgo. It runs in RE2 compatibility mode to
+// understand the patterns written for re2, like named groups '(?P<name>...)'.
+type pcreEngine struct{}
+
+type pcreRegex struct {
+	re *regexp2.Regexp
+}
+
+func (pcreEngine) Compile(pattern string) (Regex, error) {
+	re, err := regexp2.Compile(pattern, regexp2.RE2)
+	if err != nil {
+		return nil, err
+	}
+	re.MatchTimeout = pcreMatchTimeout
+
+	return pcreRegex{re: re}, nil
+}
+
+func (r pcreRegex) MatchString(s string) (bool, error) {
+	return r.re.MatchString(s)
+}
+
+func (r pcreRegex) NamedGroups(s string) (map[string]string, error) {
+	match, err := r.re.FindStringMatch(s)
+	if err != nil || match == nil {
+		return nil, err
+	}
+
+	groups := map[string]string{}
+	for _, group := range match.Groups() {
+		// unnamed groups are named by their number
+		if _, err := strconv.Atoi(group.Name); err != nil {
+			groups[group.Name] = group.String()
+		}
+	}
+
+	return groups, nil
+}
+
+func (r pcreRegex) FindString(s string) (string, error) {
+	match, err := r.re.FindStringMatch(s)
+	if err != nil || match == nil {
+		return "", err
+	}
+
+	return match.String(), nil
+}
+
+func (r pcreRegex) ReplaceAllString(s string, replacement string) (string, error) {
+	return r.re.Replace(s, replacement, -1, -1)
+}
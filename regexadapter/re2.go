@@ -0,0 +1,46 @@
+package regexadapter
+
+import "regexp"
+
+type re2Engine struct{}
+
+type re2Regex struct {
+	re *regexp.Regexp
+}
+
+func (re2Engine) Compile(pattern string) (Regex, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return re2Regex{re: re}, nil
+}
+
+func (r re2Regex) MatchString(s string) (bool, error) {
+	return r.re.MatchString(s), nil
+}
+
+func (r re2Regex) NamedGroups(s string) (map[string]string, error) {
+	matches := r.re.FindStringSubmatch(s)
+	if matches == nil {
+		return nil, nil
+	}
+
+	groups := map[string]string{}
+	for i, name := range r.re.SubexpNames() {
+		if name != "" {
+			groups[name] = matches[i]
+		}
+	}
+
+	return groups, nil
+}
+
+func (r re2Regex) FindString(s string) (string, error) {
+	return r.re.FindString(s), nil
+}
+
+func (r re2Regex) ReplaceAllString(s string, replacement string) (string, error) {
+	return r.re.ReplaceAllString(s, replacement), nil
+}
@@ -1,10 +1,160 @@
 package regexadapter
 
 import (
-	"regexp"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
 )
 
-func RegexMatchesString(pattern string, branchName string) bool {
-	return regexp.MustCompile(pattern).MatchString(branchName)
-	//return pcre.MustCompile(pattern, 0).MatcherString(branchName, 0).Matches()
+const (
+	// EngineRE2 is the regular expression engine of the go standard library. It runs in linear time but does not
+	// support lookarounds and backreferences.
+	EngineRE2 = "re2"
+	// EnginePCRE is a Perl compatible backtracking engine that supports lookarounds and backreferences.
+	EnginePCRE = "pcre"
+)
+
+type (
+	// Regex is a compiled pattern
+	Regex interface {
+		// MatchString tells if the pattern matches s.
+		MatchString(s string) (bool, error)
+		// NamedGroups returns the values of all named capture groups of the first match in s.
+		// If the pattern does not match, nil is returned.
+		NamedGroups(s string) (map[string]string, error)
+		// FindString returns the first match in s, it is empty if the pattern does not match.
+		FindString(s string) (string, error)
+		// ReplaceAllString replaces all matches in s by the replacement, which may refer groups like $1 or ${name}.
+		ReplaceAllString(s string, replacement string) (string, error)
+	}
+
+	// Engine compiles patterns of a regular expression syntax
+	Engine interface {
+		Compile(pattern string) (Regex, error)
+	}
+)
+
+var engines = map[string]Engine{
+	EngineRE2:  re2Engine{},
+	EnginePCRE: pcreEngine{},
+}
+
+var cache = struct {
+	sync.Mutex
+	patterns map[string]Regex
+}{patterns: map[string]Regex{}}
+
+// RegisterEngine makes the given engine available by its name as a pattern prefix and as default engine.
+// An engine registered by an existing name replaces that engine.
+func RegisterEngine(name string, engine Engine) {
+	cache.Lock()
+	defer cache.Unlock()
+
+	engines[name] = engine
+	cache.patterns = map[string]Regex{}
+}
+
+// Engines returns the names of the available engines in alphabetical order.
+func Engines() []string {
+	cache.Lock()
+	defer cache.Unlock()
+
+	return sortedEngineNames()
+}
+
+// Compile compiles the given pattern. The engine is selected by a prefix of the pattern, like 're2:' or 'pcre:'.
+// Patterns without prefix are compiled by the given default engine, if that is empty by re2.
+// Every pattern is compiled only once per run, later calls return the cached regular expression.
+// An invalid pattern or an unknown engine is reported as error.
+func Compile(defaultEngine string, pattern string) (Regex, error) {
+	cache.Lock()
+	defer cache.Unlock()
+
+	engineName, expression := splitEnginePrefix(defaultEngine, pattern)
+
+	cacheKey := engineName + "\x00" + expression
+	if re, ok := cache.patterns[cacheKey]; ok {
+		return re, nil
+	}
+
+	engine, ok := engines[engineName]
+	if !ok {
+		return nil, fmt.Errorf("unknown regex engine '%s', supported are: %s", engineName, strings.Join(sortedEngineNames(), ", "))
+	}
+
+	re, err := engine.Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern '%s': %v", pattern, err)
+	}
+	cache.patterns[cacheKey] = re
+
+	return re, nil
+}
+
+// RegexMatchesString tells if the given pattern matches s. The engine is selected like in Compile.
+func RegexMatchesString(defaultEngine string, pattern string, s string) (bool, error) {
+	re, err := Compile(defaultEngine, pattern)
+	if err != nil {
+		return false, err
+	}
+
+	return re.MatchString(s)
+}
+
+// RegexNamedGroups returns the values of all named capture groups of the first match of pattern in s.
+// If the pattern does not match, nil is returned. The engine is selected like in Compile.
+func RegexNamedGroups(defaultEngine string, pattern string, s string) (map[string]string, error) {
+	re, err := Compile(defaultEngine, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return re.NamedGroups(s)
+}
+
+// RegexFindString returns the first match of pattern in s. The engine is selected like in Compile.
+func RegexFindString(defaultEngine string, pattern string, s string) (string, error) {
+	re, err := Compile(defaultEngine, pattern)
+	if err != nil {
+		return "", err
+	}
+
+	return re.FindString(s)
+}
+
+// RegexReplaceAllString replaces all matches of pattern in s by the replacement. The engine is selected like in Compile.
+func RegexReplaceAllString(defaultEngine string, pattern string, s string, replacement string) (string, error) {
+	re, err := Compile(defaultEngine, pattern)
+	if err != nil {
+		return "", err
+	}
+
+	return re.ReplaceAllString(s, replacement)
+}
+
+// splitEnginePrefix returns the engine named by the prefix of the pattern and the pattern without prefix.
+// Only names of available engines are treated as prefix, so patterns like 'feat: .*' are kept as they are.
+func splitEnginePrefix(defaultEngine string, pattern string) (string, string) {
+	if i := strings.Index(pattern, ":"); i > 0 {
+		if _, ok := engines[pattern[:i]]; ok {
+			return pattern[:i], pattern[i+1:]
+		}
+	}
+
+	if defaultEngine == "" {
+		return EngineRE2, pattern
+	}
+
+	return defaultEngine, pattern
+}
+
+func sortedEngineNames() []string {
+	var names []string
+	for name := range engines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
 }
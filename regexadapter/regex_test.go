@@ -0,0 +1,173 @@
+package regexadapter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompile_CachesCompiledPattern(t *testing.T) {
+	re1, err := Compile("", `^feature/.*$`)
+	assert.NoError(t, err)
+
+	re2, err := Compile("", `^feature/.*$`)
+	assert.NoError(t, err)
+
+	assert.True(t, re1 == re2, "expected the cached regular expression to be returned")
+}
+
+func TestCompile_InvalidPattern_ReturnsError(t *testing.T) {
+	re, err := Compile("", `^feature/(.*$`)
+
+	assert.Nil(t, re)
+	assert.EqualError(t, err, "invalid pattern '^feature/(.*$': error parsing regexp: missing closing ): `^feature/(.*$`")
+}
+
+func TestCompile_UnknownEngine_ReturnsError(t *testing.T) {
+	re, err := Compile("perl", `^feature/.*$`)
+
+	assert.Nil(t, re)
+	assert.EqualError(t, err, "unknown regex engine 'perl', supported are: pcre, re2")
+}
+
+func TestCompile_SelectsEngine(t *testing.T) {
+	testDataSet := map[string]struct {
+		defaultEngine  string
+		pattern        string
+		expectedEngine Regex
+	}{
+		"no default engine":           {defaultEngine: "", pattern: `^feature/.*$`, expectedEngine: re2Regex{}},
+		"re2 default engine":          {defaultEngine: EngineRE2, pattern: `^feature/.*$`, expectedEngine: re2Regex{}},
+		"pcre default engine":         {defaultEngine: EnginePCRE, pattern: `^feature/.*$`, expectedEngine: pcreRegex{}},
+		"pcre prefix":                 {defaultEngine: "", pattern: `pcre:^feature/.*$`, expectedEngine: pcreRegex{}},
+		"re2 prefix overrides":        {defaultEngine: EnginePCRE, pattern: `re2:^feature/.*$`, expectedEngine: re2Regex{}},
+		"unknown prefix is no engine": {defaultEngine: "", pattern: `feat: .*`, expectedEngine: re2Regex{}},
+	}
+
+	for testName, testData := range testDataSet {
+		t.Run(testName, func(t *testing.T) {
+			re, err := Compile(testData.defaultEngine, testData.pattern)
+
+			assert.NoError(t, err)
+			assert.IsType(t, testData.expectedEngine, re)
+		})
+	}
+}
+
+func TestRegexMatchesString(t *testing.T) {
+	testDataSet := map[string]struct {
+		defaultEngine string
+		pattern       string
+		s             string
+		expectedMatch bool
+		expectError   bool
+	}{
+		"match":                   {pattern: `^feature/.*$`, s: "feature/PROJECT-123", expectedMatch: true},
+		"no match":                {pattern: `^feature/.*$`, s: "release/v1.0.0", expectedMatch: false},
+		"invalid pattern":         {pattern: `^feature/(.*$`, s: "feature/PROJECT-123", expectError: true},
+		"re2 lookahead":           {pattern: `^(?!.*WIP).*$`, s: "PROJECT-1 done", expectError: true},
+		"pcre lookahead":          {pattern: `pcre:^(?!.*WIP).*$`, s: "PROJECT-1 done", expectedMatch: true},
+		"pcre lookahead no match": {pattern: `pcre:^(?!.*WIP).*$`, s: "WIP PROJECT-1", expectedMatch: false},
+		"pcre default engine":     {defaultEngine: EnginePCRE, pattern: `(?<=PROJECT-)\d+`, s: "PROJECT-1", expectedMatch: true},
+		"pcre backreference":      {pattern: `pcre:^(\w+) \1$`, s: "fix fix", expectedMatch: true},
+	}
+
+	for testName, testData := range testDataSet {
+		t.Run(testName, func(t *testing.T) {
+			matches, err := RegexMatchesString(testData.defaultEngine, testData.pattern, testData.s)
+
+			assert.Exactly(t, testData.expectedMatch, matches)
+			assert.Exactly(t, testData.expectError, err != nil)
+		})
+	}
+}
+
+func TestRegexNamedGroups(t *testing.T) {
+	for _, engine := range []string{EngineRE2, EnginePCRE} {
+		t.Run(engine, func(t *testing.T) {
+			groups, err := RegexNamedGroups(engine, `^feature/(?P<ticket>[A-Z]+-[0-9]+)-(?P<description>.*)$`, "feature/PROJECT-123-add-login")
+
+			assert.NoError(t, err)
+			assert.Exactly(t, map[string]string{"ticket": "PROJECT-123", "description": "add-login"}, groups)
+		})
+	}
+}
+
+func TestRegexNamedGroups_NoMatch_ReturnsNil(t *testing.T) {
+	for _, engine := range []string{EngineRE2, EnginePCRE} {
+		t.Run(engine, func(t *testing.T) {
+			groups, err := RegexNamedGroups(engine, `^feature/(?P<ticket>.*)$`, "release/v1.0.0")
+
+			assert.NoError(t, err)
+			assert.Nil(t, groups)
+		})
+	}
+}
+
+func TestRegexNamedGroups_InvalidPattern_ReturnsError(t *testing.T) {
+	groups, err := RegexNamedGroups("", `^feature/(?P<ticket>.*$`, "feature/PROJECT-123")
+
+	assert.Error(t, err)
+	assert.Nil(t, groups)
+}
+
+func TestRegexFindString(t *testing.T) {
+	for _, engine := range []string{EngineRE2, EnginePCRE} {
+		t.Run(engine, func(t *testing.T) {
+			match, err := RegexFindString(engine, `[A-Z]+-[0-9]+`, "feature/PROJECT-123-add-login")
+			assert.NoError(t, err)
+			assert.Exactly(t, "PROJECT-123", match)
+
+			match, err = RegexFindString(engine, `[A-Z]+-[0-9]+`, "release/v1.0.0")
+			assert.NoError(t, err)
+			assert.Exactly(t, "", match)
+		})
+	}
+}
+
+func TestRegexReplaceAllString(t *testing.T) {
+	for _, engine := range []string{EngineRE2, EnginePCRE} {
+		t.Run(engine, func(t *testing.T) {
+			replaced, err := RegexReplaceAllString(engine, `(?P<type>[a-z]+)/(?P<ticket>[A-Z]+-[0-9]+)`, "feature/PROJECT-123 and bugfix/PROJECT-7", "${ticket} ($1)")
+
+			assert.NoError(t, err)
+			assert.Exactly(t, "PROJECT-123 (feature) and PROJECT-7 (bugfix)", replaced)
+		})
+	}
+}
+
+func TestRegexFindAndReplace_InvalidPattern_ReturnsError(t *testing.T) {
+	_, err := RegexFindString("", `(`, "")
+	assert.Error(t, err)
+
+	_, err = RegexReplaceAllString("", `(`, "", "")
+	assert.Error(t, err)
+}
+
+func TestRegisterEngine(t *testing.T) {
+	defer delete(engines, "fake")
+
+	RegisterEngine("fake", fakeEngine{})
+
+	matches, err := RegexMatchesString("", "fake:anything", "feature/PROJECT-123")
+
+	assert.NoError(t, err)
+	assert.True(t, matches)
+	assert.Contains(t, Engines(), "fake")
+}
+
+type fakeEngine struct{}
+
+func (fakeEngine) Compile(string) (Regex, error) { return fakeRegex{}, nil }
+
+type fakeRegex struct{}
+
+func (fakeRegex) MatchString(string) (bool, error) { return true, nil }
+
+func (fakeRegex) NamedGroups(string) (map[string]string, error) { return map[string]string{}, nil }
+
+func (fakeRegex) FindString(s string) (string, error) { return s, nil }
+
+func (fakeRegex) ReplaceAllString(_ string, replacement string) (string, error) {
+	return replacement, nil
+}
@@ -2,6 +2,7 @@ package subcommand
 
 import (
 	"sort"
+	"strings"
 
 	"os"
 
@@ -14,8 +15,8 @@ import (
 func NewDiagCommand() *DiagCommand {
 	return &DiagCommand{
 		logger:                               logger{os.Stdout},
-		findConfigurationFilePath:            config.FindConfigurationFilePath,
-		loadConfiguration:                    config.LoadConfiguration,
+		findConfigurationLayers:              config.FindConfigurationLayers,
+		loadConfigurationLayers:              config.LoadConfigurationLayers,
 		checkIsCommitHookInstalledAtPath:     isCommitHookInstalled,
 		checkIsAnotherGitHookInstalledAtPath: isAnotherGitHookInstalled,
 	}
@@ -24,45 +25,62 @@ func NewDiagCommand() *DiagCommand {
 // DiagCommand holds data and implementation of the 'diag' sub command
 type DiagCommand struct {
 	logger
-	findConfigurationFilePath            func() (string, error)
-	loadConfiguration                    func() (*config.Configuration, error)
-	checkIsCommitHookInstalledAtPath     func(string) bool
-	checkIsAnotherGitHookInstalledAtPath func(string) bool
+	findConfigurationLayers              func() ([]config.ConfigurationLayer, error)
+	loadConfigurationLayers              func([]config.ConfigurationLayer) (*config.Configuration, config.ConfigurationOrigins, error)
+	checkIsCommitHookInstalledAtPath     func(string, string) bool
+	checkIsAnotherGitHookInstalledAtPath func(string, string) bool
+	origins                              config.ConfigurationOrigins
 }
 
 // Diagnostics gives useful output about the current configuration
 func (cmd *DiagCommand) Diagnostics() int {
 
-	configurationFilePath, err := cmd.findConfigurationFilePath()
+	layers, err := cmd.findConfigurationLayers()
 	if err != nil {
 		cmd.stdoutf("error while searching configuration file: %v\n", err)
 		return 1
 	}
 
 	cmd.stdout("git-commit-hook diagnostics")
-	cmd.stdoutf("load configuration: %v\n", configurationFilePath)
+	for _, layer := range layers {
+		cmd.stdoutf("load configuration: %v (%s, %s)\n", layer.FilePath, layer.Scope, layer.Format())
+	}
 	cmd.stdout("")
 
-	configuration, err := cmd.loadConfiguration()
+	configuration, origins, err := cmd.loadConfigurationLayers(layers)
 	if err != nil {
 		cmd.stdoutf("error loading configuration: %v\n", err)
 		return 1
 	}
 
+	// with a single layer, the origin of all values is obvious
+	cmd.origins = nil
+	if len(layers) > 1 {
+		cmd.origins = origins
+	}
+
 	for projectName, projectConfiguration := range *configuration {
 		cmd.stdout("-------------------------------------------------------------------\n")
 		cmd.printProjectConfiguration(projectName, projectConfiguration)
 
-		cmd.stdout("\ngit-commit-hook installed: ")
-		if cmd.checkIsCommitHookInstalledAtPath(projectConfiguration.Path) {
-			cmd.stdout("YES")
-			cmd.stdout("\n")
-		} else {
-			cmd.stdout("NO")
-			if cmd.checkIsAnotherGitHookInstalledAtPath(projectConfiguration.Path) {
-				cmd.stdout(", another commit-msg hook is installed")
+		cmd.stdout("\n")
+		gitFolderPath, err := getGitFolderPath(projectName, projectConfiguration)
+		if err != nil {
+			cmd.stdout(err, "\n")
+			continue
+		}
+		for _, hookName := range supportedGitHookNames {
+			cmd.stdoutf("git-commit-hook installed as %s: ", hookName)
+			if cmd.checkIsCommitHookInstalledAtPath(gitFolderPath, hookName) {
+				cmd.stdout("YES")
+				cmd.stdout("\n")
+			} else {
+				cmd.stdout("NO")
+				if cmd.checkIsAnotherGitHookInstalledAtPath(gitFolderPath, hookName) {
+					cmd.stdoutf(", another %s hook is installed", hookName)
+				}
+				cmd.stdout("\n")
 			}
-			cmd.stdout("\n")
 		}
 	}
 
@@ -71,16 +89,138 @@ func (cmd *DiagCommand) Diagnostics() int {
 
 func (cmd *DiagCommand) printProjectConfiguration(projectName string, projectConfiguration config.Project) {
 	cmd.stdout("project:", projectName)
-	cmd.stdout("path   :", projectConfiguration.Path)
+	cmd.stdout("path   :", projectConfiguration.Path, cmd.origin(projectName, "path", ""))
+	if projectConfiguration.Remote != "" {
+		cmd.stdout("remote :", projectConfiguration.Remote, cmd.origin(projectName, "remote", ""))
+	}
+	if len(projectConfiguration.Extends) > 0 {
+		cmd.stdout("extends:", strings.Join(projectConfiguration.Extends, ", "), cmd.origin(projectName, "extends", ""))
+	}
+	if projectConfiguration.RegexEngine != "" {
+		cmd.stdout("regex engine:", projectConfiguration.RegexEngine, cmd.origin(projectName, "regexEngine", ""))
+	}
 	cmd.stdout("\nbranch types:\n")
-	cmd.printConfigurationMap(projectConfiguration.BranchTypes)
+	cmd.printBranchTypes(projectName, projectConfiguration.BranchTypes)
 	cmd.stdout("\nbranch type templates:\n")
-	cmd.printConfigurationMap(projectConfiguration.Templates)
+	cmd.printConfigurationMap(projectName, "template", projectConfiguration.Templates)
+	if len(projectConfiguration.PrepareTemplates) > 0 {
+		cmd.stdout("\nbranch type prepare templates:\n")
+		cmd.printConfigurationMap(projectName, "prepare", projectConfiguration.PrepareTemplates)
+	}
 	cmd.stdout("\nbranch type validation:\n")
-	cmd.printConfigurationMap(projectConfiguration.Validation)
+	cmd.printConfigurationMap(projectName, "validation", projectConfiguration.Validation)
+	if len(projectConfiguration.Rules) > 0 {
+		cmd.stdout("\nbranch type rules:\n")
+		cmd.printValidationRules(projectName, projectConfiguration.Rules)
+	}
+	if len(projectConfiguration.Conventional) > 0 {
+		cmd.stdout("\nbranch type conventional commits:\n")
+		cmd.printConventionalCommitConfigurations(projectName, projectConfiguration.Conventional)
+	}
+	if len(projectConfiguration.Trailers) > 0 {
+		cmd.stdout("\nbranch type trailers:\n")
+		cmd.printTrailerConfigurations(projectName, projectConfiguration.Trailers)
+	}
+}
+
+// origin returns a hint on the configuration layer the given value was taken from
+func (cmd *DiagCommand) origin(projectName string, section string, key string) string {
+	layer, ok := cmd.origins.Get(projectName, section, key)
+	if !ok {
+		return ""
+	}
+
+	return " (from " + string(layer.Scope) + ")"
+}
+
+func (cmd *DiagCommand) printConventionalCommitConfigurations(projectName string, conventional map[string]config.ConventionalCommitConfiguration) {
+	var keys []string
+	for k := range conventional {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		cmd.stdout("\t", k, ":", cmd.origin(projectName, "conventional", k), "\n")
+		cmd.stdout("\t\t", "types:", strings.Join(conventional[k].AllowedTypes(), ", "), "\n")
+		if len(conventional[k].Scopes) > 0 {
+			cmd.stdout("\t\t", "scopes:", strings.Join(conventional[k].Scopes, ", "), "\n")
+		}
+		if conventional[k].RequireScope {
+			cmd.stdout("\t\t", "scope required", "\n")
+		}
+	}
+}
+
+func (cmd *DiagCommand) printTrailerConfigurations(projectName string, trailers map[string]config.TrailerConfiguration) {
+	var keys []string
+	for k := range trailers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		cmd.stdout("\t", k, ":", cmd.origin(projectName, "trailers", k), "\n")
+		for _, trailerTemplate := range trailers[k].Add {
+			cmd.stdout("\t\t", "add:", trailerTemplate.Key, ": ", trailerTemplate.Value, "\n")
+		}
+		if len(trailers[k].Require) > 0 {
+			cmd.stdout("\t\t", "require:", strings.Join(trailers[k].Require, ", "), "\n")
+		}
+	}
+}
+
+func (cmd *DiagCommand) printValidationRules(projectName string, rules map[string]config.ValidationRule) {
+	var keys []string
+	for k := range rules {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		cmd.stdout("\t", k, ":", cmd.origin(projectName, "rules", k), "\n")
+		cmd.printValidationRule(rules[k], "\t\t")
+	}
+}
+
+func (cmd *DiagCommand) printValidationRule(rule config.ValidationRule, indent string) {
+	if rule.Description != "" {
+		cmd.stdout(indent, "description:", rule.Description, "\n")
+	}
+	if rule.Pattern != "" {
+		cmd.stdout(indent, "pattern:", rule.Pattern, "\n")
+	}
+	if rule.SubjectMaxLength > 0 {
+		cmd.stdoutf("%ssubjectMaxLength:%d\n", indent, rule.SubjectMaxLength)
+	}
+	cmd.printValidationRuleGroup("allOf", rule.AllOf, indent)
+	cmd.printValidationRuleGroup("anyOf", rule.AnyOf, indent)
+	cmd.printValidationRuleGroup("noneOf", rule.NoneOf, indent)
+}
+
+func (cmd *DiagCommand) printValidationRuleGroup(groupName string, rules []config.ValidationRule, indent string) {
+	if len(rules) == 0 {
+		return
+	}
+	cmd.stdout(indent, groupName, ":", "\n")
+	for _, rule := range rules {
+		cmd.stdout(indent, "-", "\n")
+		cmd.printValidationRule(rule, indent+"\t")
+	}
+}
+
+func (cmd *DiagCommand) printBranchTypes(projectName string, branchTypes config.BranchTypes) {
+	for _, branchType := range branchTypes.Ordered() {
+		origin := cmd.origin(projectName, "branch", branchType.Name)
+		if branchType.Priority != 0 {
+			cmd.stdout("\t", branchType.Name, ":", branchType.Pattern, " (priority ", branchType.Priority, ")", origin, "\n")
+			continue
+		}
+		cmd.stdout("\t", branchType.Name, ":", branchType.Pattern, origin, "\n")
+	}
 }
 
-func (cmd *DiagCommand) printConfigurationMap(m interface{}) {
+func (cmd *DiagCommand) printConfigurationMap(projectName string, section string, m interface{}) {
 	var keys []string
 
 	for _, value := range reflect.ValueOf(m).MapKeys() {
@@ -91,12 +231,10 @@ func (cmd *DiagCommand) printConfigurationMap(m interface{}) {
 
 	for _, k := range keys {
 		switch v := m.(type) {
-		case map[string]config.BranchTypePattern:
-			cmd.stdout("\t", k, ":", v[k], "\n")
 		case map[string]config.BranchTypeTemplate:
-			cmd.stdout("\t", k, ":", v[k], "\n")
+			cmd.stdout("\t", k, ":", v[k], cmd.origin(projectName, section, k), "\n")
 		case map[string]config.BranchValidationConfiguration:
-			cmd.stdout("\t", k, ":", "\n")
+			cmd.stdout("\t", k, ":", cmd.origin(projectName, section, k), "\n")
 			var keys2 []string
 			for k2 := range v[k] {
 				keys2 = append(keys2, k2)
@@ -22,7 +22,7 @@ func TestDiagCommand_Diagnostics(t *testing.T) {
 	res := diag.Diagnostics()
 
 	expectedOutput := `
-git-commit-hook diagnosticsload configuration: /tmp/git-commit-hook/git-commit-hook.yaml
+git-commit-hook diagnosticsload configuration: /tmp/git-commit-hook/git-commit-hook.yaml (repository, yaml)
 -------------------------------------------------------------------
 project:test projectpath   :/tmp/git-commit-hook/.git
 branch types:
@@ -36,7 +36,8 @@ branch type validation:
 	release:
 		(?m)(?:\s|^|/)(([A-Z](_)*)+-[0-9]+)([\s,;:!.-]|$):valid ticket ID
 
-git-commit-hook installed: NO
+git-commit-hook installed as commit-msg: NO
+git-commit-hook installed as prepare-commit-msg: NO
 `
 	assert.Exactly(t, strings.TrimLeft(expectedOutput, "\n"), diag.stdoutWriter.(*bytes.Buffer).String())
 	assert.Exactly(t, 0, res)
@@ -49,12 +50,12 @@ func TestDiagCommand_Diagnostics_CommitHookIsAlreadyInstalled(t *testing.T) {
 
 	diag := NewDiagCommand()
 	diag.stdoutWriter = bytes.NewBufferString("")
-	diag.checkIsCommitHookInstalledAtPath = func(string) bool { return true }
+	diag.checkIsCommitHookInstalledAtPath = func(string, string) bool { return true }
 
 	res := diag.Diagnostics()
 
 	expectedOutput := `
-git-commit-hook diagnosticsload configuration: /tmp/git-commit-hook/git-commit-hook.yaml
+git-commit-hook diagnosticsload configuration: /tmp/git-commit-hook/git-commit-hook.yaml (repository, yaml)
 -------------------------------------------------------------------
 project:test projectpath   :/tmp/git-commit-hook/.git
 branch types:
@@ -68,7 +69,8 @@ branch type validation:
 	release:
 		(?m)(?:\s|^|/)(([A-Z](_)*)+-[0-9]+)([\s,;:!.-]|$):valid ticket ID
 
-git-commit-hook installed: YES
+git-commit-hook installed as commit-msg: YES
+git-commit-hook installed as prepare-commit-msg: YES
 `
 	assert.Exactly(t, strings.TrimLeft(expectedOutput, "\n"), diag.stdoutWriter.(*bytes.Buffer).String())
 	assert.Exactly(t, 0, res)
@@ -81,13 +83,13 @@ func TestDiagCommand_Diagnostics_AnotherCommitHookIsAlreadyInstalled(t *testing.
 
 	diag := NewDiagCommand()
 	diag.stdoutWriter = bytes.NewBufferString("")
-	diag.checkIsCommitHookInstalledAtPath = func(string) bool { return false }
-	diag.checkIsAnotherGitHookInstalledAtPath = func(string) bool { return true }
+	diag.checkIsCommitHookInstalledAtPath = func(string, string) bool { return false }
+	diag.checkIsAnotherGitHookInstalledAtPath = func(string, string) bool { return true }
 
 	res := diag.Diagnostics()
 
 	expectedOutput := `
-git-commit-hook diagnosticsload configuration: /tmp/git-commit-hook/git-commit-hook.yaml
+git-commit-hook diagnosticsload configuration: /tmp/git-commit-hook/git-commit-hook.yaml (repository, yaml)
 -------------------------------------------------------------------
 project:test projectpath   :/tmp/git-commit-hook/.git
 branch types:
@@ -101,7 +103,8 @@ branch type validation:
 	release:
 		(?m)(?:\s|^|/)(([A-Z](_)*)+-[0-9]+)([\s,;:!.-]|$):valid ticket ID
 
-git-commit-hook installed: NO, another commit-msg hook is installed
+git-commit-hook installed as commit-msg: NO, another commit-msg hook is installed
+git-commit-hook installed as prepare-commit-msg: NO, another prepare-commit-msg hook is installed
 `
 	assert.Exactly(t, strings.TrimLeft(expectedOutput, "\n"), diag.stdoutWriter.(*bytes.Buffer).String())
 	assert.Exactly(t, 0, res)
@@ -114,7 +117,7 @@ func TestDiagCommand_Diagnostics_ConfigFilePathCannotBeFound(t *testing.T) {
 
 	diag := NewDiagCommand()
 	diag.stdoutWriter = bytes.NewBufferString("")
-	diag.findConfigurationFilePath = func() (string, error) { return "", errors.New("some error") }
+	diag.findConfigurationLayers = func() ([]config.ConfigurationLayer, error) { return nil, errors.New("some error") }
 
 	res := diag.Diagnostics()
 
@@ -132,14 +135,218 @@ func TestDiagCommand_Diagnostics_ConfigCannotBeLoad(t *testing.T) {
 
 	diag := NewDiagCommand()
 	diag.stdoutWriter = bytes.NewBufferString("")
-	diag.loadConfiguration = func() (*config.Configuration, error) { return nil, errors.New("some error") }
+	diag.loadConfigurationLayers = func([]config.ConfigurationLayer) (*config.Configuration, config.ConfigurationOrigins, error) {
+		return nil, nil, errors.New("some error")
+	}
 
 	res := diag.Diagnostics()
 
 	expectedOutput := `
-git-commit-hook diagnosticsload configuration: /tmp/git-commit-hook/git-commit-hook.yaml
+git-commit-hook diagnosticsload configuration: /tmp/git-commit-hook/git-commit-hook.yaml (repository, yaml)
 error loading configuration: some error
 `
 	assert.Exactly(t, strings.TrimLeft(expectedOutput, "\n"), diag.stdoutWriter.(*bytes.Buffer).String())
 	assert.Exactly(t, 1, res)
 }
+
+func TestDiagCommand_Diagnostics_PrintsValidationRules(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+
+	testhelper.PreapreTestEnvironment(t)
+
+	diag := NewDiagCommand()
+	diag.stdoutWriter = bytes.NewBufferString("")
+	diag.loadConfigurationLayers = func([]config.ConfigurationLayer) (*config.Configuration, config.ConfigurationOrigins, error) {
+		return &config.Configuration{
+			"rules project": config.Project{
+				Rules: map[string]config.ValidationRule{
+					"release": {
+						AllOf:  []config.ValidationRule{{Pattern: "[A-Z]+-[0-9]+"}, {SubjectMaxLength: 72}},
+						NoneOf: []config.ValidationRule{{Pattern: "WIP", Description: "not contain WIP"}},
+					},
+				},
+			},
+		}, nil, nil
+	}
+
+	diag.Diagnostics()
+
+	expectedOutput := `
+branch type rules:
+	release:
+		allOf:
+		-
+			pattern:[A-Z]+-[0-9]+
+		-
+			subjectMaxLength:72
+		noneOf:
+		-
+			description:not contain WIP
+			pattern:WIP
+`
+	assert.Contains(t, diag.stdoutWriter.(*bytes.Buffer).String(), expectedOutput)
+}
+
+func TestDiagCommand_Diagnostics_PrintsConventionalCommitConfiguration(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+
+	testhelper.PreapreTestEnvironment(t)
+
+	diag := NewDiagCommand()
+	diag.stdoutWriter = bytes.NewBufferString("")
+	diag.loadConfigurationLayers = func([]config.ConfigurationLayer) (*config.Configuration, config.ConfigurationOrigins, error) {
+		return &config.Configuration{
+			"conventional project": config.Project{
+				Conventional: map[string]config.ConventionalCommitConfiguration{
+					"*": {Types: []string{"feat", "fix"}, Scopes: []string{"api"}, RequireScope: true},
+				},
+			},
+		}, nil, nil
+	}
+
+	diag.Diagnostics()
+
+	expectedOutput := `
+branch type conventional commits:
+	*:
+		types:feat, fix
+		scopes:api
+		scope required
+`
+	assert.Contains(t, diag.stdoutWriter.(*bytes.Buffer).String(), expectedOutput)
+}
+
+func TestDiagCommand_Diagnostics_PrintsTrailerConfiguration(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+
+	testhelper.PreapreTestEnvironment(t)
+
+	diag := NewDiagCommand()
+	diag.stdoutWriter = bytes.NewBufferString("")
+	diag.loadConfigurationLayers = func([]config.ConfigurationLayer) (*config.Configuration, config.ConfigurationOrigins, error) {
+		return &config.Configuration{
+			"trailer project": config.Project{
+				Trailers: map[string]config.TrailerConfiguration{
+					"*": {
+						Add:     []config.TrailerTemplate{{Key: "Ticket", Value: "{{.Branch.ticket}}"}},
+						Require: []string{"Ticket", "Reviewed-by"},
+					},
+				},
+			},
+		}, nil, nil
+	}
+
+	diag.Diagnostics()
+
+	expectedOutput := `
+branch type trailers:
+	*:
+		add:Ticket: {{.Branch.ticket}}
+		require:Ticket, Reviewed-by
+`
+	assert.Contains(t, diag.stdoutWriter.(*bytes.Buffer).String(), expectedOutput)
+}
+
+func TestDiagCommand_Diagnostics_PathPattern_DoesNotCheckHooks(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+
+	testhelper.PreapreTestEnvironment(t)
+
+	diag := NewDiagCommand()
+	diag.stdoutWriter = bytes.NewBufferString("")
+	diag.loadConfigurationLayers = func([]config.ConfigurationLayer) (*config.Configuration, config.ConfigurationOrigins, error) {
+		return &config.Configuration{"acme services": config.Project{Path: "/work/acme/**"}}, nil, nil
+	}
+	diag.checkIsCommitHookInstalledAtPath = func(gitFolderPath string, hookName string) bool {
+		t.Fatalf("Did not expect hooks to be checked at '%s'", gitFolderPath)
+		return false
+	}
+
+	diag.Diagnostics()
+
+	output := diag.stdoutWriter.(*bytes.Buffer).String()
+	assert.Contains(t, output, "project 'acme services' covers several repositories by the path pattern '/work/acme/**', install its hooks into the repositories manually\n")
+	assert.NotContains(t, output, "git-commit-hook installed as")
+}
+
+func TestDiagCommand_Diagnostics_Preset_DoesNotCheckHooks(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+
+	testhelper.PreapreTestEnvironment(t)
+
+	diag := NewDiagCommand()
+	diag.stdoutWriter = bytes.NewBufferString("")
+	diag.loadConfigurationLayers = func([]config.ConfigurationLayer) (*config.Configuration, config.ConfigurationOrigins, error) {
+		return &config.Configuration{"team": config.Project{Extends: []string{"gitflow"}}}, nil, nil
+	}
+	diag.checkIsCommitHookInstalledAtPath = func(gitFolderPath string, hookName string) bool {
+		t.Fatalf("Did not expect hooks to be checked at '%s'", gitFolderPath)
+		return false
+	}
+
+	diag.Diagnostics()
+
+	output := diag.stdoutWriter.(*bytes.Buffer).String()
+	assert.Contains(t, output, "project 'team' has no path, it is a preset or selected by remote\n")
+	assert.NotContains(t, output, "git-commit-hook installed as")
+}
+
+func TestDiagCommand_Diagnostics_PrintsRemoteExtendsAndRegexEngine(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+
+	testhelper.PreapreTestEnvironment(t)
+
+	diag := NewDiagCommand()
+	diag.stdoutWriter = bytes.NewBufferString("")
+	diag.loadConfigurationLayers = func([]config.ConfigurationLayer) (*config.Configuration, config.ConfigurationOrigins, error) {
+		return &config.Configuration{
+			"remote project": config.Project{Remote: "git@github.com:acme/*", Extends: []string{"gitflow", "jira"}, RegexEngine: "pcre"},
+		}, nil, nil
+	}
+
+	diag.Diagnostics()
+
+	assert.Contains(t, diag.stdoutWriter.(*bytes.Buffer).String(), "path   :remote :git@github.com:acme/*extends:gitflow, jiraregex engine:pcre")
+}
+
+func TestDiagCommand_Diagnostics_PrintsFormatAndOriginOfValuesForSeveralLayers(t *testing.T) {
+	defer testhelper.CleanupTestEnvironment(t)
+
+	testhelper.PreapreTestEnvironment(t)
+
+	userLayer := config.ConfigurationLayer{Scope: config.ConfigurationScopeUser, FilePath: "/home/dev/.config/git-commit-hook/git-commit-hook.yaml"}
+	repositoryLayer := config.ConfigurationLayer{Scope: config.ConfigurationScopeRepository, FilePath: "/repo/.git-commit-hook.json"}
+
+	diag := NewDiagCommand()
+	diag.stdoutWriter = bytes.NewBufferString("")
+	diag.findConfigurationLayers = func() ([]config.ConfigurationLayer, error) {
+		return []config.ConfigurationLayer{userLayer, repositoryLayer}, nil
+	}
+	diag.loadConfigurationLayers = func([]config.ConfigurationLayer) (*config.Configuration, config.ConfigurationOrigins, error) {
+		origins := config.ConfigurationOrigins{}
+		origins.Set("acme", "path", "", userLayer)
+		origins.Set("acme", "branch", "feature", userLayer)
+		origins.Set("acme", "template", "feature", repositoryLayer)
+
+		return &config.Configuration{"acme": config.Project{
+			Path:        "~/work/acme/**",
+			BranchTypes: config.BranchTypes{{Name: "feature", Pattern: "^feature/.*$"}},
+			Templates:   map[string]config.BranchTypeTemplate{"feature": "{{.CommitMessage}}"},
+		}}, origins, nil
+	}
+
+	diag.Diagnostics()
+
+	expectedOutput := `
+git-commit-hook diagnosticsload configuration: /home/dev/.config/git-commit-hook/git-commit-hook.yaml (user, yaml)
+load configuration: /repo/.git-commit-hook.json (repository, json)
+-------------------------------------------------------------------
+project:acmepath   :~/work/acme/** (from user)
+branch types:
+	feature:^feature/.*$ (from user)
+
+branch type templates:
+	feature:{{.CommitMessage}} (from repository)
+`
+	assert.Contains(t, diag.stdoutWriter.(*bytes.Buffer).String(), strings.TrimLeft(expectedOutput, "\n"))
+}
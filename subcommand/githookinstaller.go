@@ -19,7 +19,7 @@ func NewGitHookInstaller() GitHookInstaller {
 type (
 	// GitHookInstaller implements the installation of git-commit-hook into a repository
 	GitHookInstaller interface {
-		installForProject(gitFolderPath string, forceOverwrite bool) error
+		installForProject(gitFolderPath string, hookName string, forceOverwrite bool) error
 	}
 	gitHookInstaller struct {
 		logger
@@ -30,14 +30,14 @@ type (
 	}
 )
 
-func (cmd *gitHookInstaller) installForProject(gitFolderPath string, forceOverwrite bool) error {
+func (cmd *gitHookInstaller) installForProject(gitFolderPath string, hookName string, forceOverwrite bool) error {
 
 	exeFile, err := cmd.getCurrentExecutableFilePath()
 	if err != nil {
 		return err
 	}
 
-	commitHookFilePath := createCommitHookFilePath(gitFolderPath)
+	commitHookFilePath := createCommitHookFilePath(gitFolderPath, hookName)
 
 	if cmd.existsFile(commitHookFilePath) {
 		if !forceOverwrite {
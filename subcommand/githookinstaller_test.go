@@ -24,7 +24,7 @@ func TestGitHookInstaller_Install(t *testing.T) {
 
 	installer := NewGitHookInstaller()
 
-	res := installer.installForProject(gitFolder, false)
+	res := installer.installForProject(gitFolder, gitCommitMessageHookName, false)
 
 	assertCurrentExecutableIsSymlinkedAsGitHook(t, gitHookFilePath)
 	assert.NoError(t, res)
@@ -45,7 +45,7 @@ func TestGitHookInstaller_FileAlreadyExists_NoForce(t *testing.T) {
 
 	installer := NewGitHookInstaller()
 
-	err = installer.installForProject(gitFolder, false)
+	err = installer.installForProject(gitFolder, gitCommitMessageHookName, false)
 
 	assert.Exactly(t, "file already exists, use -f to force overwriting", err.Error())
 }
@@ -65,7 +65,7 @@ func TestGitHookInstaller_FileAlreadyExists_WithForce(t *testing.T) {
 
 	installer := NewGitHookInstaller()
 
-	err = installer.installForProject(gitFolder, true)
+	err = installer.installForProject(gitFolder, gitCommitMessageHookName, true)
 
 	assert.NoError(t, err)
 	assertCurrentExecutableIsSymlinkedAsGitHook(t, gitHookFilePath)
@@ -91,7 +91,7 @@ func TestGitHookInstaller_FileAlreadyExists_WithForce_RemoveFails(t *testing.T)
 		return removeFileErrorStub
 	}
 
-	err = installer.installForProject(gitFolder, true)
+	err = installer.installForProject(gitFolder, gitCommitMessageHookName, true)
 
 	assert.Exactly(t, removeFileErrorStub, err)
 }
@@ -112,7 +112,7 @@ func TestGitHookInstaller_CreateSymlinkFails(t *testing.T) {
 		return createSymlinkErrorStub
 	}
 
-	err = installer.installForProject(gitFolder, true)
+	err = installer.installForProject(gitFolder, gitCommitMessageHookName, true)
 
 	assert.Exactly(t, createSymlinkErrorStub, err)
 }
@@ -133,7 +133,7 @@ func TestGitHookInstaller_CannotGetCurrentExecutableFilePath(t *testing.T) {
 		return "", getExecutableFilePathErrorStub
 	}
 
-	err = installer.installForProject(gitFolder, true)
+	err = installer.installForProject(gitFolder, gitCommitMessageHookName, true)
 
 	assert.Exactly(t, getExecutableFilePathErrorStub, err)
 }
@@ -6,11 +6,16 @@ import (
 	"path"
 
 	"path/filepath"
+	"strings"
 
 	"github.com/Oppodelldog/git-commit-hook/config"
+	"github.com/Oppodelldog/git-commit-hook/hook"
 )
 
-const gitCommitMessageHookName = "commit-msg"
+const gitCommitMessageHookName = hook.CommitMessageHookName
+const gitPrepareCommitMessageHookName = hook.PrepareCommitMessageHookName
+
+var supportedGitHookNames = []string{gitCommitMessageHookName, gitPrepareCommitMessageHookName}
 
 func loadProjectConfiguration() (config.Project, error) {
 	wd, err := os.Getwd()
@@ -22,14 +27,54 @@ func loadProjectConfiguration() (config.Project, error) {
 	return config.LoadProjectConfigurationFromCommitMessageFileDir(path.Join(wd, ".git/commit-message.txt"))
 }
 
-func createCommitHookFilePath(gitFolderPath string) string {
-	commitHookFilePath := path.Join(gitFolderPath, "hooks", gitCommitMessageHookName)
+// getGitFolderPath returns the path of the repository the hooks of the given project are installed to.
+// Projects without path, like presets, and projects whose path is a pattern covering several repositories
+// have no such repository, an error is returned for them.
+func getGitFolderPath(projectName string, projectConfiguration config.Project) (string, error) {
+	if projectConfiguration.Path == "" {
+		return "", fmt.Errorf("project '%s' has no path, it is a preset or selected by remote", projectName)
+	}
+
+	gitFolderPath, ok := projectConfiguration.GetRepositoryPath()
+	if !ok {
+		return "", fmt.Errorf("project '%s' covers several repositories by the path pattern '%s', install its hooks into the repositories manually", projectName, projectConfiguration.Path)
+	}
+
+	return gitFolderPath, nil
+}
+
+func createCommitHookFilePath(gitFolderPath string, hookName string) string {
+	commitHookFilePath := path.Join(gitFolderPath, "hooks", hookName)
 
 	return commitHookFilePath
 }
 
-func isAnotherGitHookInstalled(gitFolderPath string) bool {
-	commitHookFilePath := createCommitHookFilePath(gitFolderPath)
+// parseGitHookNames parses a comma separated list of hook names, it returns an error for unsupported hook names.
+func parseGitHookNames(hookNames string) ([]string, error) {
+	var parsedHookNames []string
+	for _, hookName := range strings.Split(hookNames, ",") {
+		hookName = strings.TrimSpace(hookName)
+		if !isSupportedGitHookName(hookName) {
+			return nil, fmt.Errorf("unsupported hook type '%s', supported are: %s", hookName, strings.Join(supportedGitHookNames, ", "))
+		}
+		parsedHookNames = append(parsedHookNames, hookName)
+	}
+
+	return parsedHookNames, nil
+}
+
+func isSupportedGitHookName(hookName string) bool {
+	for _, supportedHookName := range supportedGitHookNames {
+		if hookName == supportedHookName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isAnotherGitHookInstalled(gitFolderPath string, hookName string) bool {
+	commitHookFilePath := createCommitHookFilePath(gitFolderPath, hookName)
 	if _, err := os.Stat(commitHookFilePath); err == nil {
 		return true
 	}
@@ -37,8 +82,8 @@ func isAnotherGitHookInstalled(gitFolderPath string) bool {
 	return false
 }
 
-func isCommitHookInstalled(gitFolderPath string) bool {
-	commitHookFilePath := createCommitHookFilePath(gitFolderPath)
+func isCommitHookInstalled(gitFolderPath string, hookName string) bool {
+	commitHookFilePath := createCommitHookFilePath(gitFolderPath, hookName)
 	commitHookOrignFilePath, err := filepath.EvalSymlinks(commitHookFilePath)
 	if err != nil {
 		return false
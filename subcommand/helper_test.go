@@ -36,7 +36,7 @@ func TestIsAnotherGitHookInstalled(t *testing.T) {
 			testhelper.InitTestFolder(t)
 			testhelper.InitGitRepository(t, "develop")
 			testData.prepareTest()
-			res := isAnotherGitHookInstalled(testhelper.TestPathGitFolder)
+			res := isAnotherGitHookInstalled(testhelper.TestPathGitFolder, gitCommitMessageHookName)
 
 			assert.Exactly(t, testData.expectedResult, res)
 		})
@@ -83,7 +83,7 @@ func TestIsCommitHookInstalled(t *testing.T) {
 			testhelper.InitTestFolder(t)
 			testhelper.InitGitRepository(t, "develop")
 			testData.prepareTest()
-			res := isCommitHookInstalled(testhelper.TestPathGitFolder)
+			res := isCommitHookInstalled(testhelper.TestPathGitFolder, gitCommitMessageHookName)
 
 			assert.Exactly(t, testData.expectedResult, res)
 		})
@@ -29,16 +29,24 @@ func (cmd *InstallCommand) Install() int {
 	var projectName string
 	var allFlag bool
 	var forceOverwrite bool
+	var hookTypes string
 	flagSet := flag.NewFlagSet("git-commit-hook install", flag.ContinueOnError)
 	flagSet.SetOutput(cmd.stdoutWriter)
 	flagSet.StringVar(&projectName, "p", "", `project name`)
 	flagSet.BoolVar(&allFlag, "a", false, `all`)
 	flagSet.BoolVar(&forceOverwrite, "f", false, `force file creation by overwriting`)
+	flagSet.StringVar(&hookTypes, "t", gitCommitMessageHookName, `comma separated hook types to install (commit-msg, prepare-commit-msg)`)
 	err := flagSet.Parse(os.Args[2:])
 	if err != nil {
 		return 1
 	}
 
+	hookNames, err := parseGitHookNames(hookTypes)
+	if err != nil {
+		cmd.stdout(err, "\n")
+		return 1
+	}
+
 	configuration, err := cmd.loadConfiguration()
 	if err != nil {
 		cmd.stdout(err, "\n")
@@ -51,16 +59,18 @@ func (cmd *InstallCommand) Install() int {
 			cmd.stdout(err, "\n")
 			return 1
 		}
-		cmd.stdoutf("installing git-commit-hook to '%s': ", projectConfiguration.Path)
-		err = cmd.gitHookInstaller.installForProject(projectConfiguration.Path, forceOverwrite)
+		gitFolderPath, err := getGitFolderPath(projectName, projectConfiguration)
 		if err != nil {
 			cmd.stdout(err, "\n")
 			return 1
 		}
-		cmd.stdout("OK", "\n")
+		err = cmd.installForProject(gitFolderPath, hookNames, forceOverwrite)
+		if err != nil {
+			return 1
+		}
 
 	} else if allFlag {
-		err := cmd.installForAllProjects(configuration, forceOverwrite)
+		err := cmd.installForAllProjects(configuration, hookNames, forceOverwrite)
 		if err != nil {
 			cmd.stdout(err, "\n")
 			return 1
@@ -73,16 +83,17 @@ func (cmd *InstallCommand) Install() int {
 	return 0
 }
 
-func (cmd *InstallCommand) installForAllProjects(configuration *config.Configuration, forceOverwrite bool) error {
+func (cmd *InstallCommand) installForAllProjects(configuration *config.Configuration, hookNames []string, forceOverwrite bool) error {
 	var hasErrors bool
-	for _, projectConfiguration := range *configuration {
-		cmd.stdoutf("installing git-commit-hook to '%s': ", projectConfiguration.Path)
-		err := cmd.gitHookInstaller.installForProject(projectConfiguration.Path, forceOverwrite)
+	for projectName, projectConfiguration := range *configuration {
+		gitFolderPath, err := getGitFolderPath(projectName, projectConfiguration)
+		if err != nil {
+			cmd.stdout("skipping: ", err, "\n")
+			continue
+		}
+		err = cmd.installForProject(gitFolderPath, hookNames, forceOverwrite)
 		if err != nil {
-			cmd.stdout(err, "\n")
 			hasErrors = true
-		} else {
-			cmd.stdout("OK", "\n")
 		}
 	}
 	if hasErrors {
@@ -90,3 +101,19 @@ func (cmd *InstallCommand) installForAllProjects(configuration *config.Configura
 	}
 	return nil
 }
+
+func (cmd *InstallCommand) installForProject(gitFolderPath string, hookNames []string, forceOverwrite bool) error {
+	var lastErr error
+	for _, hookName := range hookNames {
+		cmd.stdoutf("installing git-commit-hook %s to '%s': ", hookName, gitFolderPath)
+		err := cmd.gitHookInstaller.installForProject(gitFolderPath, hookName, forceOverwrite)
+		if err != nil {
+			cmd.stdout(err, "\n")
+			lastErr = err
+		} else {
+			cmd.stdout("OK", "\n")
+		}
+	}
+
+	return lastErr
+}
@@ -29,6 +29,8 @@ Usage of git-commit-hook install:
   -f	force file creation by overwriting
   -p string
     	project name
+  -t string
+    	comma separated hook types to install (commit-msg, prepare-commit-msg) (default "commit-msg")
 `
 
 	assert.Exactly(t, 1, res)
@@ -74,6 +76,8 @@ Usage of git-commit-hook install:
   -f	force file creation by overwriting
   -p string
     	project name
+  -t string
+    	comma separated hook types to install (commit-msg, prepare-commit-msg) (default "commit-msg")
 `
 	assert.Exactly(t, 1, res)
 	assert.Exactly(t, strings.TrimLeft(expectedOutput, "\n"), cmd.stdoutWriter.(*bytes.Buffer).String())
@@ -93,41 +97,73 @@ func TestInstall_InstallForAllProjects(t *testing.T) {
 		"without -f": {
 			configuration: configWithTwoProjects,
 			gitHookInstallerExpectedParms: []gitHookInstallerMockParams{
-				{"pathA", false},
-				{"pathB", false},
+				{"pathA", gitCommitMessageHookName, false},
+				{"pathB", gitCommitMessageHookName, false},
 			},
 			expectedOutput: `
-installing git-commit-hook to 'pathA': OK
-installing git-commit-hook to 'pathB': OK
+installing git-commit-hook commit-msg to 'pathA': OK
+installing git-commit-hook commit-msg to 'pathB': OK
 `,
 		},
 		"with -f": {
 			additionalOsArgs: []string{"-f"},
 			configuration:    configWithTwoProjects,
 			gitHookInstallerExpectedParms: []gitHookInstallerMockParams{
-				{"pathA", true},
-				{"pathB", true},
+				{"pathA", gitCommitMessageHookName, true},
+				{"pathB", gitCommitMessageHookName, true},
 			},
 			expectedOutput: `
-installing git-commit-hook to 'pathA': OK
-installing git-commit-hook to 'pathB': OK
+installing git-commit-hook commit-msg to 'pathA': OK
+installing git-commit-hook commit-msg to 'pathB': OK
+`,
+		},
+		"with both hook types": {
+			additionalOsArgs: []string{"-t", "commit-msg,prepare-commit-msg"},
+			configuration:    &config.Configuration{"projectA": config.Project{Path: "pathA"}},
+			gitHookInstallerExpectedParms: []gitHookInstallerMockParams{
+				{"pathA", gitCommitMessageHookName, false},
+				{"pathA", gitPrepareCommitMessageHookName, false},
+			},
+			expectedOutput: `
+installing git-commit-hook commit-msg to 'pathA': OK
+installing git-commit-hook prepare-commit-msg to 'pathA': OK
+`,
+		},
+		"path pattern is skipped": {
+			configuration: &config.Configuration{"projectA": config.Project{Path: "pathA"}, "projectC": config.Project{Path: "/work/acme/**"}},
+			gitHookInstallerExpectedParms: []gitHookInstallerMockParams{
+				{"pathA", gitCommitMessageHookName, false},
+			},
+			expectedOutput: `
+installing git-commit-hook commit-msg to 'pathA': OK
+skipping: project 'projectC' covers several repositories by the path pattern '/work/acme/**', install its hooks into the repositories manually
+`,
+		},
+		"preset is skipped": {
+			configuration: &config.Configuration{"projectA": config.Project{Path: "pathA"}, "team": config.Project{Extends: []string{"gitflow"}}},
+			gitHookInstallerExpectedParms: []gitHookInstallerMockParams{
+				{"pathA", gitCommitMessageHookName, false},
+			},
+			expectedOutput: `
+installing git-commit-hook commit-msg to 'pathA': OK
+skipping: project 'team' has no path, it is a preset or selected by remote
 `,
 		},
 		"without -f and project name": {
 			additionalOsArgs: []string{"-p", "projectB"},
 			configuration:    configWithTwoProjects,
 			gitHookInstallerExpectedParms: []gitHookInstallerMockParams{
-				{"pathB", false},
+				{"pathB", gitCommitMessageHookName, false},
 			},
-			expectedOutput: "installing git-commit-hook to 'pathB': OK\n",
+			expectedOutput: "installing git-commit-hook commit-msg to 'pathB': OK\n",
 		},
 		"with -f and project name": {
 			additionalOsArgs: []string{"-f", "-p", "projectA"},
 			configuration:    configWithTwoProjects,
 			gitHookInstallerExpectedParms: []gitHookInstallerMockParams{
-				{"pathA", true},
+				{"pathA", gitCommitMessageHookName, true},
 			},
-			expectedOutput: "installing git-commit-hook to 'pathA': OK\n",
+			expectedOutput: "installing git-commit-hook commit-msg to 'pathA': OK\n",
 		},
 	}
 
@@ -185,6 +221,46 @@ project configuration not found for project name 'projectA'
 
 }
 
+func TestInstall_ProjectWithPathPattern_ShowError(t *testing.T) {
+	originArgs := os.Args
+	os.Args = []string{"programm name", "install", "-p", "projectC"}
+	defer func() { os.Args = originArgs }()
+
+	cmd := NewInstallCommand()
+	cmd.stdoutWriter = bytes.NewBufferString("")
+	cmd.loadConfiguration = func() (*config.Configuration, error) {
+		return &config.Configuration{"projectC": config.Project{Path: "/work/acme/**"}}, nil
+	}
+	cmd.gitHookInstaller = &gitHookInstallerMock{t: t}
+	res := cmd.Install()
+
+	expectedOutput := `
+project 'projectC' covers several repositories by the path pattern '/work/acme/**', install its hooks into the repositories manually
+`
+	assert.Exactly(t, 1, res)
+	assert.Exactly(t, strings.TrimLeft(expectedOutput, "\n"), cmd.stdoutWriter.(*bytes.Buffer).String())
+}
+
+func TestInstall_ProjectWithoutPath_ShowError(t *testing.T) {
+	originArgs := os.Args
+	os.Args = []string{"programm name", "install", "-p", "team"}
+	defer func() { os.Args = originArgs }()
+
+	cmd := NewInstallCommand()
+	cmd.stdoutWriter = bytes.NewBufferString("")
+	cmd.loadConfiguration = func() (*config.Configuration, error) {
+		return &config.Configuration{"team": config.Project{Extends: []string{"gitflow"}}}, nil
+	}
+	cmd.gitHookInstaller = &gitHookInstallerMock{t: t}
+	res := cmd.Install()
+
+	expectedOutput := `
+project 'team' has no path, it is a preset or selected by remote
+`
+	assert.Exactly(t, 1, res)
+	assert.Exactly(t, strings.TrimLeft(expectedOutput, "\n"), cmd.stdoutWriter.(*bytes.Buffer).String())
+}
+
 func TestInstall_GitHookInstallerReturnsError_ShowError(t *testing.T) {
 	originArgs := os.Args
 	defer func() { os.Args = originArgs }()
@@ -195,8 +271,8 @@ func TestInstall_GitHookInstallerReturnsError_ShowError(t *testing.T) {
 		additionalOsArgs []string
 		expectedOutput   string
 	}{
-		"all":            {additionalOsArgs: []string{"-a"}, expectedOutput: "installing git-commit-hook to '': some error in git hook installer\ndone with errors\n"},
-		"single project": {additionalOsArgs: []string{"-p", "projectA"}, expectedOutput: "installing git-commit-hook to '': " + errorMessageStub + "\n"},
+		"all":            {additionalOsArgs: []string{"-a"}, expectedOutput: "installing git-commit-hook commit-msg to 'pathA': some error in git hook installer\ndone with errors\n"},
+		"single project": {additionalOsArgs: []string{"-p", "projectA"}, expectedOutput: "installing git-commit-hook commit-msg to 'pathA': " + errorMessageStub + "\n"},
 	}
 	for testCaseName, testData := range testDataSet {
 		t.Run(testCaseName, func(t *testing.T) {
@@ -207,7 +283,7 @@ func TestInstall_GitHookInstallerReturnsError_ShowError(t *testing.T) {
 			cmd := NewInstallCommand()
 			cmd.stdoutWriter = bytes.NewBufferString("")
 			cmd.loadConfiguration = func() (*config.Configuration, error) {
-				return &config.Configuration{"projectA": config.Project{}}, nil
+				return &config.Configuration{"projectA": config.Project{Path: "pathA"}}, nil
 			}
 			cmd.gitHookInstaller = &gitHookInstallerErrorMock{errorMessageStub}
 			res := cmd.Install()
@@ -220,7 +296,8 @@ func TestInstall_GitHookInstallerReturnsError_ShowError(t *testing.T) {
 
 type gitHookInstallerMockParams struct {
 	p1 string
-	p2 bool
+	p2 string
+	p3 bool
 }
 type gitHookInstallerMock struct {
 	t      *testing.T
@@ -228,9 +305,9 @@ type gitHookInstallerMock struct {
 	callNo int
 }
 
-func (m *gitHookInstallerMock) installForProject(gitFolderPath string, forceOverwrite bool) error {
+func (m *gitHookInstallerMock) installForProject(gitFolderPath string, hookName string, forceOverwrite bool) error {
 	for k, v := range m.params {
-		if v.p1 == gitFolderPath && v.p2 == forceOverwrite {
+		if v.p1 == gitFolderPath && v.p2 == hookName && v.p3 == forceOverwrite {
 			m.params = append(m.params[:k], m.params[k+1:]...)
 			return nil
 		}
@@ -243,6 +320,23 @@ type gitHookInstallerErrorMock struct {
 	errorMessage string
 }
 
-func (m *gitHookInstallerErrorMock) installForProject(gitFolderPath string, forceOverwrite bool) error {
+func (m *gitHookInstallerErrorMock) installForProject(gitFolderPath string, hookName string, forceOverwrite bool) error {
 	return errors.New(m.errorMessage)
 }
+
+func TestInstall_UnsupportedHookType_ShowsError(t *testing.T) {
+	originArgs := os.Args
+	os.Args = []string{"programm name", "install", "-a", "-t", "pre-commit"}
+	defer func() { os.Args = originArgs }()
+
+	cmd := NewInstallCommand()
+	cmd.stdoutWriter = bytes.NewBufferString("")
+
+	res := cmd.Install()
+
+	expectedOutput := `
+unsupported hook type 'pre-commit', supported are: commit-msg, prepare-commit-msg
+`
+	assert.Exactly(t, 1, res)
+	assert.Exactly(t, strings.TrimLeft(expectedOutput, "\n"), cmd.stdoutWriter.(*bytes.Buffer).String())
+}
@@ -0,0 +1,67 @@
+package subcommand
+
+import (
+	"flag"
+	"os"
+
+	"github.com/Oppodelldog/git-commit-hook/config"
+)
+
+// NewLintCommand creates a new Lint sub command
+func NewLintCommand() *LintCommand {
+	return &LintCommand{
+		logger:                  logger{os.Stdout},
+		findConfigurationLayers: config.FindConfigurationLayers,
+		lintConfigurationLayers: config.LintConfigurationLayers,
+	}
+}
+
+// LintCommand holds data and implementation of the 'lint' sub command
+type LintCommand struct {
+	logger
+	findConfigurationLayers func() ([]config.ConfigurationLayer, error)
+	lintConfigurationLayers func([]config.ConfigurationLayer) []config.LintError
+}
+
+// Lint strictly checks the configuration files and reports every problem with its file and line
+func (cmd *LintCommand) Lint() int {
+
+	var filePath string
+
+	flagSet := flag.NewFlagSet("git-commit-hook lint", flag.ContinueOnError)
+	flagSet.SetOutput(cmd.stdoutWriter)
+	flagSet.StringVar(&filePath, "f", "", `configuration file to lint instead of the found configuration files`)
+	err := flagSet.Parse(os.Args[2:])
+	if err != nil {
+		return 1
+	}
+
+	var layers []config.ConfigurationLayer
+	if filePath != "" {
+		layers = []config.ConfigurationLayer{{FilePath: filePath}}
+	} else {
+		layers, err = cmd.findConfigurationLayers()
+		if err != nil {
+			cmd.stdoutf("error while searching configuration file: %v\n", err)
+			return 1
+		}
+	}
+
+	for _, layer := range layers {
+		cmd.stdoutf("lint configuration: %v\n", layer.FilePath)
+	}
+
+	lintErrors := cmd.lintConfigurationLayers(layers)
+	for _, lintError := range lintErrors {
+		cmd.stdoutf("%v\n", lintError.Error())
+	}
+
+	if len(lintErrors) > 0 {
+		cmd.stdoutf("%d problems found\n", len(lintErrors))
+		return 1
+	}
+
+	cmd.stdout("no problems found\n")
+
+	return 0
+}
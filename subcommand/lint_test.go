@@ -0,0 +1,93 @@
+package subcommand
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Oppodelldog/git-commit-hook/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintCommand_Lint_NoProblems(t *testing.T) {
+	originArgs := os.Args
+	os.Args = []string{"programm name", "lint"}
+	defer func() { os.Args = originArgs }()
+
+	lint := NewLintCommand()
+	lint.stdoutWriter = bytes.NewBufferString("")
+	lint.findConfigurationLayers = func() ([]config.ConfigurationLayer, error) {
+		return []config.ConfigurationLayer{
+			{Scope: config.ConfigurationScopeUser, FilePath: "/home/user/.config/git-commit-hook/git-commit-hook.yaml"},
+			{Scope: config.ConfigurationScopeRepository, FilePath: "/repo/.git-commit-hook.yaml"},
+		}, nil
+	}
+	var lintedLayers []config.ConfigurationLayer
+	lint.lintConfigurationLayers = func(layers []config.ConfigurationLayer) []config.LintError {
+		lintedLayers = layers
+		return nil
+	}
+
+	res := lint.Lint()
+
+	expectedOutput := `
+lint configuration: /home/user/.config/git-commit-hook/git-commit-hook.yaml
+lint configuration: /repo/.git-commit-hook.yaml
+no problems found
+`
+	assert.Exactly(t, strings.TrimLeft(expectedOutput, "\n"), lint.stdoutWriter.(*bytes.Buffer).String())
+	assert.Len(t, lintedLayers, 2)
+	assert.Exactly(t, 0, res)
+}
+
+func TestLintCommand_Lint_ProblemsFound(t *testing.T) {
+	originArgs := os.Args
+	os.Args = []string{"programm name", "lint", "-f", "/repo/.git-commit-hook.yaml"}
+	defer func() { os.Args = originArgs }()
+
+	lint := NewLintCommand()
+	lint.stdoutWriter = bytes.NewBufferString("")
+	lint.findConfigurationLayers = func() ([]config.ConfigurationLayer, error) {
+		t.Fatal("configuration layers must not be searched if a file is given")
+		return nil, nil
+	}
+	var lintedLayers []config.ConfigurationLayer
+	lint.lintConfigurationLayers = func(layers []config.ConfigurationLayer) []config.LintError {
+		lintedLayers = layers
+		return []config.LintError{
+			{FilePath: "/repo/.git-commit-hook.yaml", Line: 3, Column: 5, Message: "unknown key 'tempalte', did you mean 'template'?"},
+			{FilePath: "/repo/.git-commit-hook.yaml", Line: 7, Column: 9, Message: "unknown branch type 'featrue'"},
+		}
+	}
+
+	res := lint.Lint()
+
+	expectedOutput := `
+lint configuration: /repo/.git-commit-hook.yaml
+/repo/.git-commit-hook.yaml:3:5: unknown key 'tempalte', did you mean 'template'?
+/repo/.git-commit-hook.yaml:7:9: unknown branch type 'featrue'
+2 problems found
+`
+	assert.Exactly(t, strings.TrimLeft(expectedOutput, "\n"), lint.stdoutWriter.(*bytes.Buffer).String())
+	assert.Exactly(t, []config.ConfigurationLayer{{FilePath: "/repo/.git-commit-hook.yaml"}}, lintedLayers)
+	assert.Exactly(t, 1, res)
+}
+
+func TestLintCommand_Lint_ErrorFindingConfiguration(t *testing.T) {
+	originArgs := os.Args
+	os.Args = []string{"programm name", "lint"}
+	defer func() { os.Args = originArgs }()
+
+	lint := NewLintCommand()
+	lint.stdoutWriter = bytes.NewBufferString("")
+	lint.findConfigurationLayers = func() ([]config.ConfigurationLayer, error) {
+		return nil, errors.New("no config found")
+	}
+
+	res := lint.Lint()
+
+	assert.Exactly(t, "error while searching configuration file: no config found\n", lint.stdoutWriter.(*bytes.Buffer).String())
+	assert.Exactly(t, 1, res)
+}
@@ -18,6 +18,9 @@ func NewTestCommand() *TestCommand {
 		loadProjectConfigurationByName:         config.LoadProjectConfigurationByName,
 		loadProjectConfigurationFromWorkingDir: loadProjectConfiguration,
 		newCommitMessageModifier:               newCommitMessageModifier,
+		isSkippedByEnvironment:                 config.IsSkippedByEnvironment,
+		getBranchNameFromEnvironment:           config.GetBranchNameFromEnvironment,
+		getProjectNameFromEnvironment:          config.GetProjectNameFromEnvironment,
 	}
 }
 
@@ -29,9 +32,13 @@ type TestCommand struct {
 	loadProjectConfigurationByName         func(string) (config.Project, error)
 	loadProjectConfigurationFromWorkingDir func() (config.Project, error)
 	newCommitMessageModifier               func(projectConfiguration config.Project) hook.CommitMessageModifier
+	isSkippedByEnvironment                 func() bool
+	getBranchNameFromEnvironment           func() string
+	getProjectNameFromEnvironment          func() string
 }
 
-// Test helps to test configuration against manual input to simulate real commit situations
+// Test helps to test configuration against manual input to simulate real commit situations.
+// GIT_COMMIT_HOOK_BRANCH and GIT_COMMIT_HOOK_PROJECT are used if -b and -p are not given.
 func (cmd *TestCommand) Test() int {
 
 	var commitMessage string
@@ -48,6 +55,18 @@ func (cmd *TestCommand) Test() int {
 		return 1
 	}
 
+	if cmd.isSkippedByEnvironment() {
+		cmd.stdoutf("%s is set, the hook would leave the commit message unchanged\n", config.EnvSkip)
+		return 0
+	}
+
+	if branchName == "" {
+		branchName = cmd.getBranchNameFromEnvironment()
+	}
+	if projectName == "" {
+		projectName = cmd.getProjectNameFromEnvironment()
+	}
+
 	configurationFilePath, err := cmd.findConfigurationFilePath()
 	if err != nil {
 		cmd.stdoutf("error while searching configuration file: %v\n", err)
@@ -85,8 +104,14 @@ func (cmd *TestCommand) Test() int {
 	if projectName != "" {
 		cmd.stdoutf("project        : %s\n", projectName)
 	}
+	branchType, err := projectConfiguration.GetBranchType(branchName)
+	if err != nil {
+		cmd.stdout(err, "\n")
+		return 1
+	}
+
 	cmd.stdoutf("branch name    : %s\n", branchName)
-	cmd.stdoutf("branch type    : %s\n", projectConfiguration.GetBranchType(branchName))
+	cmd.stdoutf("branch type    : %s\n", branchType)
 	cmd.stdoutf("commit message : %s\n", commitMessage)
 	cmd.stdout("\n")
 
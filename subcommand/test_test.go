@@ -296,3 +296,67 @@ type commitMessageModifierMock struct{}
 func (m *commitMessageModifierMock) ModifyGitCommitMessage(string, string) (string, error) {
 	return "", errors.New("some error while modifying the commit mesage")
 }
+
+func TestTestCommand_Test_SkippedByEnvironment(t *testing.T) {
+	originArgs := os.Args
+	os.Args = []string{"programm name", "test", "-m", "test commit message"}
+	defer func() { os.Args = originArgs }()
+
+	test := NewTestCommand()
+	test.isSkippedByEnvironment = func() bool { return true }
+	test.stdoutWriter = bytes.NewBufferString("")
+
+	res := test.Test()
+
+	expectedOutput := `
+GIT_COMMIT_HOOK_SKIP is set, the hook would leave the commit message unchanged
+`
+	assert.Exactly(t, strings.TrimLeft(expectedOutput, "\n"), test.stdoutWriter.(*bytes.Buffer).String())
+	assert.Exactly(t, 0, res)
+}
+
+func TestTestCommand_Test_BranchAndProjectFromEnvironment(t *testing.T) {
+	originArgs := os.Args
+	os.Args = []string{"programm name", "test", "-m", "test commit message"}
+	defer func() { os.Args = originArgs }()
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.PreapreTestEnvironment(t)
+
+	test := NewTestCommand()
+	test.getBranchNameFromEnvironment = func() string { return "feature/PROJECT-123" }
+	test.getProjectNameFromEnvironment = func() string { return "test project" }
+	test.stdoutWriter = bytes.NewBufferString("")
+
+	res := test.Test()
+
+	expectedOutput := `
+testing configuration '/tmp/git-commit-hook/git-commit-hook.yaml':
+project        : test project
+branch name    : feature/PROJECT-123
+branch type    : feature
+commit message : test commit message
+
+would generate the following commit message:
+feature/PROJECT-123: test commit message
+`
+	assert.Exactly(t, strings.TrimLeft(expectedOutput, "\n"), test.stdoutWriter.(*bytes.Buffer).String())
+	assert.Exactly(t, 0, res)
+}
+
+func TestTestCommand_Test_ParametersOverrideEnvironment(t *testing.T) {
+	originArgs := os.Args
+	os.Args = []string{"programm name", "test", "-m", "PROJECT-123 test commit message", "-b", "release/1.0", "-p", "test project"}
+	defer func() { os.Args = originArgs }()
+	defer testhelper.CleanupTestEnvironment(t)
+	testhelper.PreapreTestEnvironment(t)
+
+	test := NewTestCommand()
+	test.getBranchNameFromEnvironment = func() string { return "feature/PROJECT-123" }
+	test.getProjectNameFromEnvironment = func() string { return "unknown project" }
+	test.stdoutWriter = bytes.NewBufferString("")
+
+	res := test.Test()
+
+	assert.Contains(t, test.stdoutWriter.(*bytes.Buffer).String(), "project        : test project\nbranch name    : release/1.0\nbranch type    : release\n")
+	assert.Exactly(t, 0, res)
+}
@@ -28,15 +28,23 @@ func NewUninstallerCommand() *UninstallCommand {
 func (u *UninstallCommand) Uninstall() int {
 	var projectName string
 	var allFlag bool
+	var hookTypes string
 	flagSet := flag.NewFlagSet("git-commit-hook uninstall", flag.ContinueOnError)
 	flagSet.SetOutput(u.stdoutWriter)
 	flagSet.StringVar(&projectName, "p", "", `project name`)
 	flagSet.BoolVar(&allFlag, "a", false, `all`)
+	flagSet.StringVar(&hookTypes, "t", gitCommitMessageHookName, `comma separated hook types to uninstall (commit-msg, prepare-commit-msg)`)
 	err := flagSet.Parse(os.Args[2:])
 	if err != nil {
 		return 1
 	}
 
+	hookNames, err := parseGitHookNames(hookTypes)
+	if err != nil {
+		u.stdout(err, "\n")
+		return 1
+	}
+
 	configuration, err := u.loadConfiguration()
 	if err != nil {
 		u.stdout(err, "\n")
@@ -49,13 +57,18 @@ func (u *UninstallCommand) Uninstall() int {
 			u.stdout(err, "\n")
 			return 1
 		}
-		err = u.uninstallForProject(projectConfiguraiton.Path)
+		gitFolderPath, err := getGitFolderPath(projectName, projectConfiguraiton)
+		if err != nil {
+			u.stdout(err, "\n")
+			return 1
+		}
+		err = u.uninstallForProject(gitFolderPath, hookNames)
 		if err != nil {
 			u.stdout(err, "\n")
 			return 1
 		}
 	} else if allFlag {
-		err := u.uninstallForAllProject(configuration)
+		err := u.uninstallForAllProject(configuration, hookNames)
 		if err != nil {
 			u.stdout(err, "\n")
 			return 1
@@ -67,10 +80,15 @@ func (u *UninstallCommand) Uninstall() int {
 	return 0
 }
 
-func (u *UninstallCommand) uninstallForAllProject(configuration *config.Configuration) error {
+func (u *UninstallCommand) uninstallForAllProject(configuration *config.Configuration, hookNames []string) error {
 	var hasErrors bool
-	for _, projectConfiguration := range *configuration {
-		err := u.uninstallForProject(projectConfiguration.Path)
+	for projectName, projectConfiguration := range *configuration {
+		gitFolderPath, err := getGitFolderPath(projectName, projectConfiguration)
+		if err != nil {
+			u.stdout("skipping: ", err, "\n")
+			continue
+		}
+		err = u.uninstallForProject(gitFolderPath, hookNames)
 		if err != nil {
 			hasErrors = true
 		}
@@ -81,9 +99,21 @@ func (u *UninstallCommand) uninstallForAllProject(configuration *config.Configur
 	return nil
 }
 
-func (u *UninstallCommand) uninstallForProject(gitFolderPath string) error {
+func (u *UninstallCommand) uninstallForProject(gitFolderPath string, hookNames []string) error {
+	var lastErr error
+	for _, hookName := range hookNames {
+		err := u.uninstallHookForProject(gitFolderPath, hookName)
+		if err != nil {
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
+
+func (u *UninstallCommand) uninstallHookForProject(gitFolderPath string, hookName string) error {
 
-	commitHookFilePath := createCommitHookFilePath(gitFolderPath)
+	commitHookFilePath := createCommitHookFilePath(gitFolderPath, hookName)
 
 	u.stdoutf("uninstalling git-commit-hook from '%s': ", commitHookFilePath)
 
@@ -64,6 +64,8 @@ Usage of git-commit-hook uninstall:
   -a	all
   -p string
     	project name
+  -t string
+    	comma separated hook types to uninstall (commit-msg, prepare-commit-msg) (default "commit-msg")
 `
 	assert.Exactly(t, 0, res)
 	assert.Exactly(t, strings.TrimLeft(expectedOutput, "\n"), cmd.stdoutWriter.(*bytes.Buffer).String())
@@ -86,6 +88,8 @@ Usage of git-commit-hook uninstall:
   -a	all
   -p string
     	project name
+  -t string
+    	comma separated hook types to uninstall (commit-msg, prepare-commit-msg) (default "commit-msg")
 `
 
 	assert.Exactly(t, strings.TrimLeft(expectedOutput, "\n"), uninstaller.stdoutWriter.(*bytes.Buffer).String())
@@ -135,6 +139,22 @@ uninstalling git-commit-hook from 'pathB/hooks/commit-msg': OK
 			configuration:    configWithTwoProjects,
 			expectedOutput:   "uninstalling git-commit-hook from 'pathB/hooks/commit-msg': OK\n",
 		},
+		"all, preset is skipped": {
+			additionalOsArgs: []string{"-a"},
+			configuration:    &config.Configuration{"projectA": config.Project{Path: "pathA"}, "team": config.Project{Extends: []string{"gitflow"}}},
+			expectedOutput: `
+uninstalling git-commit-hook from 'pathA/hooks/commit-msg': OK
+skipping: project 'team' has no path, it is a preset or selected by remote
+`,
+		},
+		"all, path pattern is skipped": {
+			additionalOsArgs: []string{"-a"},
+			configuration:    &config.Configuration{"projectA": config.Project{Path: "pathA"}, "projectC": config.Project{Path: "/work/acme/**"}},
+			expectedOutput: `
+uninstalling git-commit-hook from 'pathA/hooks/commit-msg': OK
+skipping: project 'projectC' covers several repositories by the path pattern '/work/acme/**', install its hooks into the repositories manually
+`,
+		},
 	}
 
 	for testCaseName, testData := range testDataSet {
@@ -207,6 +227,52 @@ done with errors
 	}
 }
 
+func TestUninstallCommand_Uninstall_ProjectWithPathPattern_ShowError(t *testing.T) {
+	originArgs := os.Args
+	os.Args = []string{"programm name", "uninstall", "-p", "projectC"}
+	defer func() { os.Args = originArgs }()
+
+	cmd := NewUninstallerCommand()
+	cmd.stdoutWriter = bytes.NewBufferString("")
+	cmd.loadConfiguration = func() (*config.Configuration, error) {
+		return &config.Configuration{"projectC": config.Project{Path: "/work/acme/**"}}, nil
+	}
+	cmd.deleteFile = func(filePath string) error {
+		t.Fatalf("Did not expect deleteFile to be called, but got: %s", filePath)
+		return nil
+	}
+	res := cmd.Uninstall()
+
+	expectedOutput := `
+project 'projectC' covers several repositories by the path pattern '/work/acme/**', install its hooks into the repositories manually
+`
+	assert.Exactly(t, 1, res)
+	assert.Exactly(t, strings.TrimLeft(expectedOutput, "\n"), cmd.stdoutWriter.(*bytes.Buffer).String())
+}
+
+func TestUninstallCommand_Uninstall_ProjectWithoutPath_ShowError(t *testing.T) {
+	originArgs := os.Args
+	os.Args = []string{"programm name", "uninstall", "-p", "team"}
+	defer func() { os.Args = originArgs }()
+
+	cmd := NewUninstallerCommand()
+	cmd.stdoutWriter = bytes.NewBufferString("")
+	cmd.loadConfiguration = func() (*config.Configuration, error) {
+		return &config.Configuration{"team": config.Project{Extends: []string{"gitflow"}}}, nil
+	}
+	cmd.deleteFile = func(filePath string) error {
+		t.Fatalf("Did not expect deleteFile to be called, but got: %s", filePath)
+		return nil
+	}
+	res := cmd.Uninstall()
+
+	expectedOutput := `
+project 'team' has no path, it is a preset or selected by remote
+`
+	assert.Exactly(t, 1, res)
+	assert.Exactly(t, strings.TrimLeft(expectedOutput, "\n"), cmd.stdoutWriter.(*bytes.Buffer).String())
+}
+
 func TestUninstallCommand_Uninstall_ProjectNameCannotBeFound_ShowError(t *testing.T) {
 
 	originArgs := os.Args
@@ -0,0 +1,126 @@
+package templatefuncs
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/Oppodelldog/git-commit-hook/regexadapter"
+)
+
+// ticketPattern matches ticket IDs like 'PROJECT-123' or 'MY_PROJ-7'
+var ticketPattern = regexp.MustCompile(`[A-Z][A-Z0-9_]*-[0-9]+`)
+
+// FuncMap returns the functions available in commit message templates. Patterns of regexFind and regexReplace are
+// compiled by the given regex engine, unless they select another one by a prefix like 'pcre:'.
+// The value a function works on is its last argument, so functions can be chained in pipelines like
+// {{.BranchName | ticketFromBranch | lower}}.
+func FuncMap(regexEngine string) template.FuncMap {
+	return template.FuncMap{
+		"upper":   strings.ToUpper,
+		"lower":   strings.ToLower,
+		"trim":    strings.TrimSpace,
+		"replace": replace,
+		"regexFind": func(pattern string, s string) (string, error) {
+			return regexadapter.RegexFindString(regexEngine, pattern, s)
+		},
+		"regexReplace": func(pattern string, replacement string, s string) (string, error) {
+			return regexadapter.RegexReplaceAllString(regexEngine, pattern, s, replacement)
+		},
+		"firstLine":        firstLine,
+		"body":             body,
+		"wrap":             wrap,
+		"default":          defaultValue,
+		"ticketFromBranch": ticketFromBranch,
+		"hasPrefix":        hasPrefix,
+	}
+}
+
+// replace replaces all occurrences of old in s by new
+func replace(old string, new string, s string) string {
+	return strings.Replace(s, old, new, -1)
+}
+
+// firstLine returns the first line of s, which is the subject of a commit message
+func firstLine(s string) string {
+	return strings.SplitN(s, "\n", 2)[0]
+}
+
+// body returns everything after the first line of s without the blank lines separating it from the subject
+func body(s string) string {
+	parts := strings.SplitN(s, "\n", 2)
+	if len(parts) < 2 {
+		return ""
+	}
+
+	return strings.TrimLeft(parts[1], "\r\n")
+}
+
+// wrap breaks the lines of s at spaces, so no line exceeds the given width. Words longer than width are kept whole.
+func wrap(width int, s string) string {
+	if width <= 0 {
+		return s
+	}
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = wrapLine(width, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func wrapLine(width int, line string) string {
+	var wrapped strings.Builder
+	lineLength := 0
+	for _, word := range strings.Fields(line) {
+		if lineLength > 0 && lineLength+1+len(word) > width {
+			wrapped.WriteString("\n")
+			lineLength = 0
+		} else if lineLength > 0 {
+			wrapped.WriteString(" ")
+			lineLength++
+		}
+		wrapped.WriteString(word)
+		lineLength += len(word)
+	}
+
+	return wrapped.String()
+}
+
+// defaultValue returns the given value, or defaultVal if the value is empty, like an empty string, list or nil
+func defaultValue(defaultVal interface{}, value interface{}) interface{} {
+	if isEmpty(value) {
+		return defaultVal
+	}
+
+	return value
+}
+
+func isEmpty(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	default:
+		return v.IsZero()
+	}
+}
+
+// ticketFromBranch returns the first ticket ID in the given branch name, like 'PROJECT-123' of
+// 'feature/PROJECT-123-add-login'. It is empty if the branch name holds no ticket ID.
+func ticketFromBranch(branchName string) string {
+	return ticketPattern.FindString(branchName)
+}
+
+// hasPrefix tells whether s starts with prefix
+func hasPrefix(prefix string, s string) bool {
+	return strings.HasPrefix(s, prefix)
+}
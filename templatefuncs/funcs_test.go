@@ -0,0 +1,84 @@
+package templatefuncs
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuncMap(t *testing.T) {
+	data := map[string]interface{}{
+		"BranchName":    "feature/PROJECT-123-add-login",
+		"CommitMessage": "Add login form\n\nThe form validates the email address before it is sent.",
+		"Empty":         "",
+		"NoDirs":        []string{},
+		"Dirs":          []string{"svc-auth"},
+	}
+
+	testDataSet := map[string]struct {
+		template string
+		expected string
+	}{
+		"upper":                   {`{{upper "abc"}}`, "ABC"},
+		"lower":                   {`{{.BranchName | lower}}`, "feature/project-123-add-login"},
+		"trim":                    {`{{trim "  abc \n"}}`, "abc"},
+		"replace":                 {`{{.BranchName | replace "/" "-"}}`, "feature-PROJECT-123-add-login"},
+		"regexFind":               {`{{.BranchName | regexFind "[A-Z]+-[0-9]+"}}`, "PROJECT-123"},
+		"regexFind, no match":     {`{{.BranchName | regexFind "JIRA-[0-9]+"}}`, ""},
+		"regexReplace":            {`{{.BranchName | regexReplace "^(\\w+)/.*$" "$1"}}`, "feature"},
+		"regexReplace, pcre":      {`{{.BranchName | regexReplace "pcre:(?<=/)[A-Z]+" "JIRA"}}`, "feature/JIRA-123-add-login"},
+		"firstLine":               {`{{.CommitMessage | firstLine}}`, "Add login form"},
+		"body":                    {`{{.CommitMessage | body}}`, "The form validates the email address before it is sent."},
+		"body of subject":         {`{{"subject" | body}}`, ""},
+		"wrap":                    {`{{.CommitMessage | body | wrap 20}}`, "The form validates\nthe email address\nbefore it is sent."},
+		"default of empty string": {`{{.Empty | default "none"}}`, "none"},
+		"default of missing key":  {`{{.Missing | default "none"}}`, "none"},
+		"default of empty list":   {`{{.NoDirs | default "root"}}`, "root"},
+		"default of value":        {`{{.BranchName | default "none"}}`, "feature/PROJECT-123-add-login"},
+		"ticketFromBranch":        {`{{.BranchName | ticketFromBranch}}`, "PROJECT-123"},
+		"ticketFromBranch, none":  {`{{"develop" | ticketFromBranch}}`, ""},
+		"hasPrefix":               {`{{if hasPrefix "feature/" .BranchName}}feat{{end}}`, "feat"},
+		"hasPrefix, not":          {`{{if .BranchName | hasPrefix "release/"}}release{{end}}`, ""},
+		"chained":                 {`[{{index .Dirs 0}}] {{.BranchName | ticketFromBranch | lower}}: {{.CommitMessage | firstLine}}`, "[svc-auth] project-123: Add login form"},
+	}
+
+	for testName, testData := range testDataSet {
+		t.Run(testName, func(t *testing.T) {
+			tmpl, err := template.New("").Option("missingkey=zero").Funcs(FuncMap("")).Parse(testData.template)
+			assert.NoError(t, err)
+
+			buffer := bytes.NewBufferString("")
+			err = tmpl.Execute(buffer, data)
+
+			assert.NoError(t, err)
+			assert.Exactly(t, testData.expected, buffer.String())
+		})
+	}
+}
+
+func TestFuncMap_InvalidPattern_ReturnsError(t *testing.T) {
+	tmpl := template.Must(template.New("").Funcs(FuncMap("")).Parse(`{{"abc" | regexFind "("}}`))
+
+	err := tmpl.Execute(bytes.NewBufferString(""), nil)
+
+	assert.Contains(t, err.Error(), "invalid pattern '('")
+}
+
+func TestFuncMap_RegexEngine(t *testing.T) {
+	tmpl := template.Must(template.New("").Funcs(FuncMap("pcre")).Parse(`{{"PROJECT-123" | regexFind "(?<=-)\\d+"}}`))
+	buffer := bytes.NewBufferString("")
+
+	err := tmpl.Execute(buffer, nil)
+
+	assert.NoError(t, err)
+	assert.Exactly(t, "123", buffer.String())
+}
+
+func TestWrap(t *testing.T) {
+	assert.Exactly(t, "a b\nc", wrap(3, "a b c"))
+	assert.Exactly(t, "averylongword\nb", wrap(3, "averylongword b"))
+	assert.Exactly(t, "a b\n\nc d", wrap(3, "a b\n\nc d"))
+	assert.Exactly(t, "a b c", wrap(0, "a b c"))
+}
@@ -6,9 +6,6 @@ import (
 	"os/exec"
 	"path"
 	"testing"
-
-	"github.com/Oppodelldog/git-commit-hook/config"
-	"gopkg.in/yaml.v2"
 )
 
 // TestPath holds the absolute path to the test folder
@@ -20,7 +17,7 @@ const TestPathGitFolder = "/tmp/git-commit-hook/.git"
 // TestPathHooksFolder holds the hooks folder inside the .git folder for the test
 const TestPathHooksFolder = "/tmp/git-commit-hook/.git/hooks"
 
-//InitGitRepository initializes a test git repository with the given branch name in the current directory
+// InitGitRepository initializes a test git repository with the given branch name in the current directory
 func InitGitRepository(t *testing.T, branchName string) {
 	t.Helper()
 	Git(t, "init")
@@ -42,28 +39,19 @@ func WriteConfigFile(t *testing.T, dir string) {
 	if err != nil {
 		t.Fatalf("could write config file, err in os.MkdirAll: %v", err)
 	}
-	cfg := config.Configuration{
-		"test project": config.Project{
-			Path: TestPathGitFolder,
-			BranchTypes: map[string]config.BranchTypePattern{
-				"feature": `^feature/PROJECT-123$`,
-				"release": `^release.*$`,
-			},
-			Templates: map[string]config.BranchTypeTemplate{
-				"feature": "{{.BranchName}}: {{.CommitMessage}}",
-			},
-			Validation: map[string]config.BranchValidationConfiguration{
-				"release": {
-					"(?m)(?:\\s|^|/)(([A-Z](_)*)+-[0-9]+)([\\s,;:!.-]|$)": "valid ticket ID",
-				},
-			},
-		},
-	}
-
-	configBytes, err := yaml.Marshal(cfg)
-	if err != nil {
-		t.Fatalf("Did not expect yaml.Marshal to return an error, but got: %v ", err)
-	}
+	configBytes := []byte(`test project:
+  path: ` + TestPathGitFolder + `
+  branch:
+  - name: feature
+    pattern: ^feature/PROJECT-123$
+  - name: release
+    pattern: ^release.*$
+  template:
+    feature: '{{.BranchName}}: {{.CommitMessage}}'
+  validation:
+    release:
+      (?m)(?:\s|^|/)(([A-Z](_)*)+-[0-9]+)([\s,;:!.-]|$): valid ticket ID
+`)
 
 	err = ioutil.WriteFile(path.Join(dir, "git-commit-hook.yaml"), configBytes, 0666)
 	if err != nil {